@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/certs/install"
+)
+
+var (
+	certsCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "certs",
+		Short:   "Manage locally trusted TLS certificates",
+	}
+
+	noTrust bool
+
+	certsInstallCmd = &cobra.Command{
+		Use:   "install",
+		Short: "Create a local CA and issue certificates for *.localhost",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return install.Run(cmd.Context(), !noTrust, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	installFlags := certsInstallCmd.Flags()
+	installFlags.BoolVar(&noTrust, "no-trust", false, "Skip installing the local CA into the system trust store.")
+	certsCmd.AddCommand(certsInstallCmd)
+	rootCmd.AddCommand(certsCmd)
+}
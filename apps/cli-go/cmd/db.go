@@ -239,11 +239,16 @@ var (
 		Value:   "none",
 	}
 
+	lintOutput = utils.EnumFlag{
+		Allowed: lint.AllowedOutputs,
+		Value:   lint.AllowedOutputs[0],
+	}
+
 	dbLintCmd = &cobra.Command{
 		Use:   "lint",
 		Short: "Checks local database for typing error",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return lint.Run(cmd.Context(), schema, level.Value, lintFailOn.Value, flags.DbConfig, afero.NewOsFs())
+			return lint.Run(cmd.Context(), schema, level.Value, lintFailOn.Value, lintOutput.Value, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 
@@ -330,6 +335,13 @@ without the envelope.`,
 		Value:   "none",
 	}
 
+	updateBaseline bool
+
+	advisorOutput = utils.EnumFlag{
+		Allowed: advisors.AllowedOutputs,
+		Value:   advisors.AllowedOutputs[0],
+	}
+
 	dbAdvisorsCmd = &cobra.Command{
 		Use:   "advisors",
 		Short: "Checks database for security and performance issues",
@@ -347,9 +359,9 @@ without the envelope.`,
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if flag := cmd.Flags().Lookup("linked"); flag != nil && flag.Changed {
-				return advisors.RunLinked(cmd.Context(), advisorType.Value, advisorLevel.Value, advisorFailOn.Value, flags.ProjectRef)
+				return advisors.RunLinked(cmd.Context(), advisorType.Value, advisorLevel.Value, advisorFailOn.Value, advisorOutput.Value, updateBaseline, flags.ProjectRef, flags.DbConfig, afero.NewOsFs())
 			}
-			return advisors.RunLocal(cmd.Context(), advisorType.Value, advisorLevel.Value, advisorFailOn.Value, flags.DbConfig)
+			return advisors.RunLocal(cmd.Context(), advisorType.Value, advisorLevel.Value, advisorFailOn.Value, advisorOutput.Value, updateBaseline, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 )
@@ -461,6 +473,7 @@ func init() {
 	lintFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
 	lintFlags.Var(&level, "level", "Error level to emit.")
 	lintFlags.Var(&lintFailOn, "fail-on", "Error level to exit with non-zero status.")
+	lintFlags.VarP(&lintOutput, "output", "o", "Output format of lint results.")
 	dbCmd.AddCommand(dbLintCmd)
 	// Build start command
 	startFlags := dbStartCmd.Flags()
@@ -491,6 +504,8 @@ func init() {
 	advisorsFlags.Var(&advisorType, "type", "Type of advisors to check: all, security, performance.")
 	advisorsFlags.Var(&advisorLevel, "level", "Minimum issue level to display: info, warn, error.")
 	advisorsFlags.Var(&advisorFailOn, "fail-on", "Issue level to exit with non-zero status: none, info, warn, error.")
+	advisorsFlags.VarP(&advisorOutput, "output", "o", "Output format of advisor results: json, sarif.")
+	advisorsFlags.BoolVar(&updateBaseline, "update-baseline", false, "Records current issues in "+utils.AdvisorsBaselinePath+" so that only new issues are reported.")
 	dbCmd.AddCommand(dbAdvisorsCmd)
 	rootCmd.AddCommand(dbCmd)
 }
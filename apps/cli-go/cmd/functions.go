@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
@@ -9,7 +11,9 @@ import (
 	"github.com/supabase/cli/internal/functions/delete"
 	"github.com/supabase/cli/internal/functions/deploy"
 	"github.com/supabase/cli/internal/functions/download"
+	"github.com/supabase/cli/internal/functions/invoke"
 	"github.com/supabase/cli/internal/functions/list"
+	"github.com/supabase/cli/internal/functions/logs"
 	new_ "github.com/supabase/cli/internal/functions/new"
 	"github.com/supabase/cli/internal/functions/serve"
 	"github.com/supabase/cli/internal/utils"
@@ -33,6 +37,29 @@ var (
 		},
 	}
 
+	functionsLogsOptions logs.Options
+	functionsLogLevel    = utils.EnumFlag{
+		Allowed: []string{"debug", "info", "warn", "error"},
+	}
+
+	functionsLogsCmd = &cobra.Command{
+		Use:   "logs [Function name]",
+		Short: "Show logs of deployed Functions",
+		Long:  "Show logs of Functions deployed to the linked Supabase project. If no function name is provided, shows logs of all functions.",
+		Example: `  supabase functions logs hello-world --since 30m
+  supabase functions logs --follow --level error
+  supabase functions logs hello-world -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slug := ""
+			if len(args) > 0 {
+				slug = args[0]
+			}
+			functionsLogsOptions.Level = functionsLogLevel.Value
+			return logs.Run(cmd.Context(), slug, flags.ProjectRef, functionsLogsOptions, afero.NewOsFs())
+		},
+	}
+
 	functionsDeleteCmd = &cobra.Command{
 		Use:   "delete <Function name>",
 		Short: "Delete a Function from Supabase",
@@ -66,6 +93,7 @@ var (
 	noVerifyJWT     = new(bool)
 	importMapPath   string
 	prune           bool
+	forceDeploy     bool
 
 	functionsDeployCmd = &cobra.Command{
 		Use:   "deploy [Function name]",
@@ -81,7 +109,7 @@ var (
 			} else if maxJobs > 1 {
 				return errors.New("--jobs must be used together with --use-api")
 			}
-			return deploy.Run(cmd.Context(), args, useDocker, noVerifyJWT, importMapPath, maxJobs, prune, afero.NewOsFs())
+			return deploy.Run(cmd.Context(), args, useDocker, noVerifyJWT, importMapPath, maxJobs, prune, forceDeploy, dryRun, afero.NewOsFs())
 		},
 	}
 
@@ -144,11 +172,40 @@ var (
 			return serve.Run(cmd.Context(), envFilePath, noVerifyJWT, importMapPath, runtimeOption, afero.NewOsFs())
 		},
 	}
+
+	invokeLocal   bool
+	invokeOptions invoke.Options
+
+	functionsInvokeCmd = &cobra.Command{
+		Use:   "invoke <Function name>",
+		Short: "Invoke a Function",
+		Long:  "Invoke a Function deployed to the linked Supabase project, or served locally with --local.",
+		Args:  cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if invokeLocal {
+				cmd.GroupID = groupLocalDev
+			}
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRef := flags.ProjectRef
+			if invokeLocal {
+				projectRef = ""
+			}
+			return invoke.Run(cmd.Context(), args[0], projectRef, invokeOptions, os.Stdout, afero.NewOsFs())
+		},
+	}
 )
 
 func init() {
 	functionsListCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	markFlagTelemetrySafe(functionsListCmd.Flags().Lookup("project-ref"))
+	logsFlags := functionsLogsCmd.Flags()
+	logsFlags.BoolVarP(&functionsLogsOptions.Follow, "follow", "f", false, "Poll for new log lines until interrupted.")
+	logsFlags.Var(&functionsLogLevel, "level", "Only show log lines at or above this level.")
+	logsFlags.DurationVar(&functionsLogsOptions.Since, "since", time.Hour, "Show logs since a relative duration (e.g. 10m).")
+	logsFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	markFlagTelemetrySafe(logsFlags.Lookup("project-ref"))
 	functionsDeleteCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	markFlagTelemetrySafe(functionsDeleteCmd.Flags().Lookup("project-ref"))
 	deployFlags := functionsDeployCmd.Flags()
@@ -161,6 +218,8 @@ func init() {
 	deployFlags.UintVarP(&maxJobs, "jobs", "j", 1, "Maximum number of parallel jobs.")
 	deployFlags.BoolVar(noVerifyJWT, "no-verify-jwt", false, "Disable JWT verification for the Function.")
 	deployFlags.BoolVar(&prune, "prune", false, "Delete Functions that exist in Supabase project but not locally.")
+	deployFlags.BoolVar(&forceDeploy, "force", false, "Redeploy all Functions even if they are unchanged.")
+	deployFlags.BoolVar(&dryRun, "dry-run", false, "Print the Functions that would be deployed without deploying them.")
 	deployFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	markFlagTelemetrySafe(deployFlags.Lookup("project-ref"))
 	deployFlags.StringVar(&importMapPath, "import-map", "", "Path to import map file.")
@@ -183,11 +242,23 @@ func init() {
 	cobra.CheckErr(downloadFlags.MarkHidden("legacy-bundle"))
 	cobra.CheckErr(downloadFlags.MarkHidden("use-docker"))
 	functionsNewCmd.Flags().Var(&authMode, "auth", "use a specific auth mode")
+	invokeFlags := functionsInvokeCmd.Flags()
+	invokeFlags.StringVarP(&invokeOptions.Data, "data", "d", "", "Request body, or @path to read it from a file.")
+	invokeFlags.StringVarP(&invokeOptions.Method, "method", "X", "POST", "HTTP method of the request.")
+	invokeFlags.StringArrayVarP(&invokeOptions.Headers, "header", "H", []string{}, "Custom request header in key:value format.")
+	invokeFlags.StringVar(&invokeOptions.Role, "role", "anon", "Role to invoke the Function as.")
+	invokeFlags.StringVar(&invokeOptions.Subject, "sub", "", "User ID to set as the JWT subject (local only).")
+	invokeFlags.BoolVar(&invokeLocal, "local", false, "Invoke the Function served locally.")
+	invokeFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	markFlagTelemetrySafe(invokeFlags.Lookup("project-ref"))
+	functionsInvokeCmd.MarkFlagsMutuallyExclusive("local", "project-ref")
 	functionsCmd.AddCommand(functionsListCmd)
+	functionsCmd.AddCommand(functionsLogsCmd)
 	functionsCmd.AddCommand(functionsDeleteCmd)
 	functionsCmd.AddCommand(functionsDeployCmd)
 	functionsCmd.AddCommand(functionsNewCmd)
 	functionsCmd.AddCommand(functionsServeCmd)
 	functionsCmd.AddCommand(functionsDownloadCmd)
+	functionsCmd.AddCommand(functionsInvokeCmd)
 	rootCmd.AddCommand(functionsCmd)
 }
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/images/load"
+	"github.com/supabase/cli/internal/images/save"
+)
+
+var (
+	imagesCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "images",
+		Short:   "Manage docker images of the local stack",
+	}
+
+	imagesSaveCmd = &cobra.Command{
+		Use:   "save <path>",
+		Short: "Save images required by the local stack to an archive",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return save.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
+	imagesLoadCmd = &cobra.Command{
+		Use:   "load <path>",
+		Short: "Load images from an archive created by images save",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return load.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	imagesCmd.AddCommand(imagesSaveCmd)
+	imagesCmd.AddCommand(imagesLoadCmd)
+	rootCmd.AddCommand(imagesCmd)
+}
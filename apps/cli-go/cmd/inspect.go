@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/inspect"
@@ -8,15 +10,20 @@ import (
 	"github.com/supabase/cli/internal/inspect/blocking"
 	"github.com/supabase/cli/internal/inspect/calls"
 	"github.com/supabase/cli/internal/inspect/db_stats"
+	"github.com/supabase/cli/internal/inspect/index_advisor"
 	"github.com/supabase/cli/internal/inspect/index_stats"
 	"github.com/supabase/cli/internal/inspect/locks"
 	"github.com/supabase/cli/internal/inspect/long_running_queries"
 	"github.com/supabase/cli/internal/inspect/outliers"
+	"github.com/supabase/cli/internal/inspect/output"
 	"github.com/supabase/cli/internal/inspect/replication_slots"
 	"github.com/supabase/cli/internal/inspect/role_stats"
+	"github.com/supabase/cli/internal/inspect/statements"
 	"github.com/supabase/cli/internal/inspect/table_stats"
+	"github.com/supabase/cli/internal/inspect/top"
 	"github.com/supabase/cli/internal/inspect/traffic_profile"
 	"github.com/supabase/cli/internal/inspect/vacuum_stats"
+	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 )
 
@@ -136,6 +143,53 @@ var (
 		},
 	}
 
+	topInterval time.Duration
+
+	inspectTopCmd = &cobra.Command{
+		Use:   "top",
+		Short: "Show a live dashboard of active queries, locks, and connections",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return top.Run(cmd.Context(), topInterval, flags.DbConfig)
+		},
+	}
+
+	inspectStatementsCmd = &cobra.Command{
+		Use:   "statements",
+		Short: "Save and compare snapshots of pg_stat_statements",
+	}
+
+	inspectStatementsSnapshotCmd = &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save a snapshot of pg_stat_statements counters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return statements.Save(cmd.Context(), flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
+	regressionThreshold float64
+
+	inspectStatementsCompareCmd = &cobra.Command{
+		Use:   "compare [old] [new]",
+		Short: "Compare two snapshots of pg_stat_statements",
+		Args:  cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return statements.Compare(cmd.Context(), args, regressionThreshold, afero.NewOsFs())
+		},
+	}
+
+	advisorFile           string
+	advisorLimit          uint
+	advisorMinImprovement float64
+	advisorMigration      string
+
+	inspectIndexAdvisorCmd = &cobra.Command{
+		Use:   "index-advisor",
+		Short: "Recommend missing indexes for the top queries using HypoPG",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return index_advisor.Run(cmd.Context(), advisorFile, advisorLimit, advisorMinImprovement, advisorMigration, flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
 	inspectCacheHitCmd = &cobra.Command{
 		Deprecated: `use "db-stats" instead.`,
 		Use:        "cache-hit",
@@ -253,6 +307,20 @@ var (
 			return inspect.Report(cmd.Context(), outputDir, flags.DbConfig, afero.NewOsFs())
 		},
 	}
+
+	reportDiffOutput = utils.EnumFlag{
+		Allowed: inspect.DiffOutputs,
+		Value:   inspect.DiffOutputs[0],
+	}
+
+	reportDiffCmd = &cobra.Command{
+		Use:   "diff <old-dir> <new-dir>",
+		Short: "Compare the CSV output of two inspect reports",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return inspect.Diff(cmd.Context(), args[0], args[1], reportDiffOutput.Value, afero.NewOsFs())
+		},
+	}
 )
 
 func init() {
@@ -261,6 +329,9 @@ func init() {
 	inspectFlags.Bool("linked", true, "Inspect the linked project.")
 	inspectFlags.Bool("local", false, "Inspect the local database.")
 	inspectCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	dbFlags := inspectDBCmd.PersistentFlags()
+	dbFlags.VarP(&output.Format, "output", "o", "Output format of inspect results.")
+	dbFlags.StringVar(&output.FailIf, "fail-if", "", "Exit with non-zero code if any result row matches this SQL expression.")
 	inspectDBCmd.AddCommand(inspectReplicationSlotsCmd)
 	inspectDBCmd.AddCommand(inspectIndexStatsCmd)
 	inspectDBCmd.AddCommand(inspectLocksCmd)
@@ -274,6 +345,19 @@ func init() {
 	inspectDBCmd.AddCommand(inspectTrafficProfileCmd)
 	inspectDBCmd.AddCommand(inspectRoleStatsCmd)
 	inspectDBCmd.AddCommand(inspectDBStatsCmd)
+	inspectTopCmd.Flags().DurationVar(&topInterval, "interval", 2*time.Second, "Time to wait between refreshes.")
+	inspectDBCmd.AddCommand(inspectTopCmd)
+	inspectStatementsCmd.AddCommand(inspectStatementsSnapshotCmd)
+	compareFlags := inspectStatementsCompareCmd.Flags()
+	compareFlags.Float64Var(&regressionThreshold, "threshold", 20, "Percentage increase in mean execution time to flag as a regression.")
+	inspectStatementsCmd.AddCommand(inspectStatementsCompareCmd)
+	inspectDBCmd.AddCommand(inspectStatementsCmd)
+	advisorFlags := inspectIndexAdvisorCmd.Flags()
+	advisorFlags.StringVarP(&advisorFile, "file", "f", "", "Path to a SQL file of queries to analyse instead of pg_stat_statements.")
+	advisorFlags.UintVar(&advisorLimit, "limit", 10, "Number of top queries by total execution time to analyse.")
+	advisorFlags.Float64Var(&advisorMinImprovement, "min-improvement", 10, "Minimum percentage reduction in query cost to recommend an index.")
+	advisorFlags.StringVar(&advisorMigration, "save", "", "Save recommended indexes as a new migration with this name.")
+	inspectDBCmd.AddCommand(inspectIndexAdvisorCmd)
 	// DEPRECATED
 	inspectDBCmd.AddCommand(inspectCacheHitCmd)
 	inspectDBCmd.AddCommand(inspectIndexUsageCmd)
@@ -289,6 +373,9 @@ func init() {
 	inspectDBCmd.AddCommand(inspectRoleConnectionsCmd)
 	inspectCmd.AddCommand(inspectDBCmd)
 	reportCmd.Flags().StringVar(&outputDir, "output-dir", ".", "Path to save CSV files in")
+	diffFlags := reportDiffCmd.Flags()
+	diffFlags.VarP(&reportDiffOutput, "output", "o", "Output format of report diff.")
+	reportCmd.AddCommand(reportDiffCmd)
 	inspectCmd.AddCommand(reportCmd)
 	rootCmd.AddCommand(inspectCmd)
 }
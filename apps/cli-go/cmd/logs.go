@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/logs"
+)
+
+var (
+	logsOptions logs.Options
+
+	logsCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "logs [service...]",
+		Short:   "Show logs of local Supabase services",
+		Example: `  supabase logs auth rest --since 10m
+  supabase logs --follow --grep "permission denied"
+  supabase logs db -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return logs.Run(cmd.Context(), args, logsOptions, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	flags := logsCmd.Flags()
+	flags.BoolVarP(&logsOptions.Follow, "follow", "f", false, "Stream new log lines as they are written.")
+	flags.StringVar(&logsOptions.Since, "since", "", "Show logs since a timestamp (e.g. 2024-01-02T13:23:37Z) or relative duration (e.g. 10m).")
+	flags.StringVar(&logsOptions.Grep, "grep", "", "Only show log lines whose message matches this regular expression.")
+	rootCmd.AddCommand(logsCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/mail"
+	"github.com/supabase/cli/internal/mail/list"
+	"github.com/supabase/cli/internal/mail/show"
+	"github.com/supabase/cli/internal/mail/wait"
+)
+
+var (
+	mailCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "mail",
+		Short:   "Inspect emails captured by the local mail server",
+	}
+
+	mailFilter  mail.Filter
+	mailLimit   uint
+	mailTimeout time.Duration
+	mailSince   time.Duration
+
+	mailListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List captured emails, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), mailFilter, mailLimit, afero.NewOsFs())
+		},
+	}
+
+	mailShowCmd = &cobra.Command{
+		Use:   "show [id]",
+		Short: "Show a captured email with its magic links and OTP codes",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := "latest"
+			if len(args) > 0 {
+				id = args[0]
+			}
+			return show.Run(cmd.Context(), id, afero.NewOsFs())
+		},
+	}
+
+	mailWaitCmd = &cobra.Command{
+		Use:     "wait",
+		Short:   "Wait for a matching email to be captured",
+		Example: `  supabase mail wait --to user@example.com --subject "Confirm Your Signup" --timeout 30s -o json`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return wait.Run(cmd.Context(), mailFilter, mailSince, mailTimeout, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	listFlags := mailListCmd.Flags()
+	listFlags.StringVar(&mailFilter.To, "to", "", "Only list emails sent to this address.")
+	listFlags.StringVar(&mailFilter.Subject, "subject", "", "Only list emails whose subject contains this text.")
+	listFlags.UintVar(&mailLimit, "limit", 50, "Maximum number of emails to list.")
+	mailCmd.AddCommand(mailListCmd)
+	mailCmd.AddCommand(mailShowCmd)
+	waitFlags := mailWaitCmd.Flags()
+	waitFlags.StringVar(&mailFilter.To, "to", "", "Wait for an email sent to this address.")
+	waitFlags.StringVar(&mailFilter.Subject, "subject", "", "Wait for an email whose subject contains this text.")
+	waitFlags.DurationVar(&mailTimeout, "timeout", 30*time.Second, "Maximum time to wait for a matching email.")
+	waitFlags.DurationVar(&mailSince, "since", 0, "Also accept emails captured up to this long before waiting started.")
+	mailCmd.AddCommand(mailWaitCmd)
+	rootCmd.AddCommand(mailCmd)
+}
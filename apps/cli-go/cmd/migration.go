@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/supabase/cli/internal/migration/down"
 	"github.com/supabase/cli/internal/migration/fetch"
+	"github.com/supabase/cli/internal/migration/lint"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/new"
 	"github.com/supabase/cli/internal/migration/repair"
@@ -96,6 +97,24 @@ var (
 		},
 	}
 
+	migrationLintOutput = utils.EnumFlag{
+		Allowed: lint.AllowedOutputs,
+		Value:   lint.OutputText,
+	}
+
+	migrationLintFailOn = utils.EnumFlag{
+		Allowed: append([]string{"none"}, lint.AllowedLevels...),
+		Value:   "none",
+	}
+
+	migrationLintCmd = &cobra.Command{
+		Use:   "lint [path] ...",
+		Short: "Lint migration files without a database connection",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lint.Run(cmd.Context(), args, migrationLintOutput.Value, migrationLintFailOn.Value, afero.NewOsFs())
+		},
+	}
+
 	migrationFetchCmd = &cobra.Command{
 		Use:   "fetch",
 		Short: "Fetch migration files from history table",
@@ -164,5 +183,10 @@ func init() {
 	migrationCmd.AddCommand(migrationFetchCmd)
 	// Build new command
 	migrationCmd.AddCommand(migrationNewCmd)
+	// Build lint command
+	migrationLintFlags := migrationLintCmd.Flags()
+	migrationLintFlags.VarP(&migrationLintOutput, "output", "o", "Output format of lint results.")
+	migrationLintFlags.Var(&migrationLintFailOn, "fail-on", "Error level to exit with non-zero status.")
+	migrationCmd.AddCommand(migrationLintCmd)
 	rootCmd.AddCommand(migrationCmd)
 }
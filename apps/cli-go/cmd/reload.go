@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/start"
+)
+
+var (
+	reloadExcluded []string
+	reloadDryRun   bool
+
+	reloadCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "reload",
+		Short:   "Apply config changes to running local Supabase containers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			validateExcludedContainers(reloadExcluded)
+			return start.Reload(cmd.Context(), afero.NewOsFs(), reloadExcluded, reloadDryRun)
+		},
+	}
+)
+
+func init() {
+	flags := reloadCmd.Flags()
+	names := strings.Join(allowedContainers, ",")
+	flags.StringSliceVarP(&reloadExcluded, "exclude", "x", []string{}, "Names of containers to not start. ["+names+"]")
+	flags.BoolVar(&reloadDryRun, "dry-run", false, "Print the containers that would be recreated without applying changes.")
+	rootCmd.AddCommand(reloadCmd)
+}
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -64,6 +65,12 @@ var experimental = []*cobra.Command{
 	dbDeclarativeCmd,
 }
 
+// Commands under a database flag subtree that only read local files
+var offline = []*cobra.Command{
+	inspectStatementsCompareCmd,
+	reportDiffCmd,
+}
+
 func IsExperimental(cmd *cobra.Command) bool {
 	for _, exp := range experimental {
 		if cmd == exp || cmd.Parent() == exp {
@@ -115,8 +122,10 @@ var (
 					}
 				}
 			}
-			if err := flags.ParseDatabaseConfig(ctx, cmd.Flags(), fsys); err != nil {
-				return err
+			if !slices.Contains(offline, cmd) {
+				if err := flags.ParseDatabaseConfig(ctx, cmd.Flags(), fsys); err != nil {
+					return err
+				}
 			}
 			// Prepare context
 			if viper.GetBool("DEBUG") {
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/sms/list"
+	"github.com/supabase/cli/internal/sms/wait"
+)
+
+var (
+	smsCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "sms",
+		Short:   "Inspect SMS captured by the local auth server",
+	}
+
+	smsTo      string
+	smsLimit   uint
+	smsTimeout time.Duration
+
+	smsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List captured SMS, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), smsTo, smsLimit, afero.NewOsFs())
+		},
+	}
+
+	smsWaitCmd = &cobra.Command{
+		Use:     "wait",
+		Short:   "Wait for an SMS to be captured",
+		Example: `  supabase sms wait --to +15555550100 --timeout 30s -o json`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return wait.Run(cmd.Context(), smsTo, smsTimeout, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	listFlags := smsListCmd.Flags()
+	listFlags.StringVar(&smsTo, "to", "", "Only list SMS sent to this phone number.")
+	listFlags.UintVar(&smsLimit, "limit", 50, "Maximum number of SMS to list.")
+	smsCmd.AddCommand(smsListCmd)
+	waitFlags := smsWaitCmd.Flags()
+	waitFlags.StringVar(&smsTo, "to", "", "Wait for an SMS sent to this phone number.")
+	waitFlags.DurationVar(&smsTimeout, "timeout", 30*time.Second, "Maximum time to wait for a matching SMS.")
+	smsCmd.AddCommand(smsWaitCmd)
+	rootCmd.AddCommand(smsCmd)
+}
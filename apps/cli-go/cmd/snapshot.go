@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/snapshot/delete"
+	"github.com/supabase/cli/internal/snapshot/list"
+	"github.com/supabase/cli/internal/snapshot/restore"
+	"github.com/supabase/cli/internal/snapshot/save"
+)
+
+var (
+	snapshotCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "snapshot",
+		Short:   "Manage named snapshots of local data",
+	}
+
+	snapshotSaveCmd = &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save local database and storage volumes as a named snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return save.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
+	snapshotRestoreCmd = &cobra.Command{
+		Use:   "restore <name>",
+		Short: "Restore local data from a named snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restore.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
+	snapshotListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List saved snapshots",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return list.Run(cmd.Context(), afero.NewOsFs())
+		},
+	}
+
+	snapshotDeleteCmd = &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a saved snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return delete.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	snapshotCmd.AddCommand(snapshotDeleteCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
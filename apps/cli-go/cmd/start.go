@@ -40,6 +40,8 @@ var (
 	excludedContainers []string
 	ignoreHealthCheck  bool
 	preview            bool
+	composePath        string
+	autoPorts          bool
 
 	startCmd = &cobra.Command{
 		GroupID: groupLocalDev,
@@ -47,7 +49,11 @@ var (
 		Short:   "Start containers for Supabase local development",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			validateExcludedContainers(excludedContainers)
-			return start.Run(cmd.Context(), afero.NewOsFs(), excludedContainers, ignoreHealthCheck)
+			fsys := afero.NewOsFs()
+			if len(composePath) > 0 {
+				return start.ExportCompose(cmd.Context(), composePath, excludedContainers, fsys)
+			}
+			return start.Run(cmd.Context(), fsys, excludedContainers, ignoreHealthCheck, autoPorts)
 		},
 	}
 )
@@ -57,6 +63,8 @@ func init() {
 	names := strings.Join(allowedContainers, ",")
 	flags.StringSliceVarP(&excludedContainers, "exclude", "x", []string{}, "Names of containers to not start. ["+names+"]")
 	flags.BoolVar(&ignoreHealthCheck, "ignore-health-check", false, "Ignore unhealthy services and exit 0")
+	flags.BoolVar(&autoPorts, "auto-ports", false, "Use free host ports and a per-worktree project id to run alongside other stacks.")
+	flags.StringVar(&composePath, "export-compose", "", "Path to write the stack as a docker compose file instead of starting it.")
 	flags.BoolVar(&preview, "preview", false, "Connect to feature preview branch")
 	cobra.CheckErr(flags.MarkHidden("preview"))
 	rootCmd.AddCommand(startCmd)
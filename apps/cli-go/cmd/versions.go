@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/versions"
+	"github.com/supabase/cli/internal/versions/pin"
+)
+
+var (
+	versionsCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "versions",
+		Short:   "Show local, linked and latest versions of Supabase services",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return versions.Run(cmd.Context(), afero.NewOsFs())
+		},
+	}
+
+	pinSource = utils.EnumFlag{
+		Allowed: []string{
+			versions.SourceLocal,
+			versions.SourceLinked,
+			versions.SourceLatest,
+			versions.SourceBundled,
+		},
+		Value: versions.SourceLocal,
+	}
+
+	versionsPinCmd = &cobra.Command{
+		Use:   "pin",
+		Short: "Pin all service versions in " + utils.VersionLockPath,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pin.Run(cmd.Context(), pinSource.Value, false, afero.NewOsFs())
+		},
+	}
+
+	syncSource = utils.EnumFlag{
+		Allowed: []string{
+			versions.SourceLinked,
+			versions.SourceLatest,
+			versions.SourceBundled,
+		},
+		Value: versions.SourceLinked,
+	}
+
+	versionsSyncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Update pinned service versions in " + utils.VersionLockPath,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pin.Run(cmd.Context(), syncSource.Value, true, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	versionsPinCmd.Flags().Var(&pinSource, "from", "Source of the service versions to pin.")
+	versionsCmd.AddCommand(versionsPinCmd)
+	versionsSyncCmd.Flags().Var(&syncSource, "from", "Source of the service versions to sync.")
+	versionsCmd.AddCommand(versionsSyncCmd)
+	rootCmd.AddCommand(versionsCmd)
+}
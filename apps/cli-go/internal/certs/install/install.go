@@ -0,0 +1,189 @@
+package install
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	// Browsers reject leaf certificates valid for longer than 825 days
+	certValidity = 825 * 24 * time.Hour
+	caValidity   = 10 * 365 * 24 * time.Hour
+
+	certDNSNames = []string{"localhost", "*.localhost"}
+	certIPs      = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+)
+
+func Run(ctx context.Context, trust bool, fsys afero.Fs) error {
+	dir, err := utils.GetLocalCertsDir()
+	if err != nil {
+		return err
+	}
+	caCert, caKey, err := loadOrCreateCA(dir, fsys)
+	if err != nil {
+		return err
+	}
+	if err := issueCert(dir, caCert, caKey, fsys); err != nil {
+		return err
+	}
+	caPath := filepath.Join(dir, utils.LocalCaCertFile)
+	fmt.Fprintln(os.Stderr, "Issued certificate for", strings.Join(certDNSNames, ", ")+":", utils.Bold(filepath.Join(dir, utils.LocalCertFile)))
+	if trust {
+		if err := installTrust(ctx, caPath, fsys); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Set %s in %s and restart to serve local services over HTTPS.\n", utils.Aqua("api.tls.enabled = true"), utils.Bold(utils.ConfigPath))
+	return nil
+}
+
+func loadOrCreateCA(dir string, fsys afero.Fs) (*x509.Certificate, crypto.Signer, error) {
+	certPath := filepath.Join(dir, utils.LocalCaCertFile)
+	keyPath := filepath.Join(dir, utils.LocalCaKeyFile)
+	if certPem, err := afero.ReadFile(fsys, certPath); err == nil {
+		keyPem, err := afero.ReadFile(fsys, keyPath)
+		if err != nil {
+			return nil, nil, errors.Errorf("failed to read CA key: %w", err)
+		}
+		return parseCA(certPem, keyPem)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, errors.Errorf("failed to read CA cert: %w", err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to generate CA key: %w", err)
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{"Supabase CLI"},
+			CommonName:   utils.LocalCaName,
+		},
+		NotBefore:             now,
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, key.Public(), key)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to create CA cert: %w", err)
+	}
+	if err := writeKeyPair(certPath, keyPath, der, key, fsys); err != nil {
+		return nil, nil, err
+	}
+	fmt.Fprintln(os.Stderr, "Created local CA:", utils.Bold(certPath))
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to parse CA cert: %w", err)
+	}
+	return cert, key, nil
+}
+
+func parseCA(certPem, keyPem []byte) (*x509.Certificate, crypto.Signer, error) {
+	certBlock, _ := pem.Decode(certPem)
+	if certBlock == nil {
+		return nil, nil, errors.New("failed to decode CA cert")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to parse CA cert: %w", err)
+	}
+	keyBlock, _ := pem.Decode(keyPem)
+	if keyBlock == nil {
+		return nil, nil, errors.New("failed to decode CA key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to parse CA key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, errors.Errorf("unsupported CA key type: %T", key)
+	}
+	return cert, signer, nil
+}
+
+func issueCert(dir string, caCert *x509.Certificate, caKey crypto.Signer, fsys afero.Fs) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return errors.Errorf("failed to generate key: %w", err)
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization: []string{"Supabase CLI local development"},
+			CommonName:   certDNSNames[0],
+		},
+		DNSNames:    certDNSNames,
+		IPAddresses: certIPs,
+		NotBefore:   now,
+		NotAfter:    now.Add(certValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, caCert, key.Public(), caKey)
+	if err != nil {
+		return errors.Errorf("failed to create cert: %w", err)
+	}
+	certPath := filepath.Join(dir, utils.LocalCertFile)
+	keyPath := filepath.Join(dir, utils.LocalKeyFile)
+	return writeKeyPair(certPath, keyPath, der, key, fsys)
+}
+
+func writeKeyPair(certPath, keyPath string, der []byte, key crypto.Signer, fsys afero.Fs) error {
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return errors.Errorf("failed to encode key: %w", err)
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(certPath)); err != nil {
+		return err
+	}
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := afero.WriteFile(fsys, certPath, certPem, 0644); err != nil {
+		return errors.Errorf("failed to write cert: %w", err)
+	}
+	// Private keys are only readable by the current user
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+	if err := afero.WriteFile(fsys, keyPath, keyPem, 0600); err != nil {
+		return errors.Errorf("failed to write key: %w", err)
+	}
+	return nil
+}
+
+func newSerialNumber() (*big.Int, error) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serial, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return nil, errors.Errorf("failed to generate serial number: %w", err)
+	}
+	return serial, nil
+}
@@ -0,0 +1,77 @@
+package install
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func parseCert(t *testing.T, fsys afero.Fs, path string) *x509.Certificate {
+	data, err := afero.ReadFile(fsys, path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestInstallCommand(t *testing.T) {
+	t.Setenv("HOME", "/home/test")
+	dir := filepath.Join("/home/test", ".supabase", "certs")
+
+	t.Run("issues certificate signed by local CA", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		ca := parseCert(t, fsys, filepath.Join(dir, utils.LocalCaCertFile))
+		assert.True(t, ca.IsCA)
+		assert.Equal(t, utils.LocalCaName, ca.Subject.CommonName)
+		cert := parseCert(t, fsys, filepath.Join(dir, utils.LocalCertFile))
+		roots := x509.NewCertPool()
+		roots.AddCert(ca)
+		for _, name := range []string{"localhost", "studio.localhost", "127.0.0.1"} {
+			_, err := cert.Verify(x509.VerifyOptions{DNSName: name, Roots: roots})
+			assert.NoError(t, err, name)
+		}
+		info, err := fsys.Stat(filepath.Join(dir, utils.LocalCaKeyFile))
+		require.NoError(t, err)
+		assert.Equal(t, "-rw-------", info.Mode().Perm().String())
+	})
+
+	t.Run("reuses existing CA", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, Run(context.Background(), false, fsys))
+		before, err := afero.ReadFile(fsys, filepath.Join(dir, utils.LocalCaCertFile))
+		require.NoError(t, err)
+		// Run test
+		err = Run(context.Background(), false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		after, err := afero.ReadFile(fsys, filepath.Join(dir, utils.LocalCaCertFile))
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("throws error on malformed CA", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, utils.LocalCaCertFile), []byte("malformed"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, utils.LocalCaKeyFile), []byte("malformed"), 0600))
+		// Run test
+		err := Run(context.Background(), false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to decode CA cert")
+	})
+}
@@ -0,0 +1,68 @@
+//go:build linux
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type trustStore struct {
+	dir  string
+	name string
+	cmd  []string
+}
+
+// Anchor directories of common distros, in order of preference
+var systemTrustStores = []trustStore{
+	// Debian, Ubuntu, Alpine
+	{dir: "/usr/local/share/ca-certificates", name: "supabase-local-ca.crt", cmd: []string{"update-ca-certificates"}},
+	// Fedora, RHEL
+	{dir: "/etc/pki/ca-trust/source/anchors", name: "supabase-local-ca.pem", cmd: []string{"update-ca-trust", "extract"}},
+	// Arch
+	{dir: "/etc/ca-certificates/trust-source/anchors", name: "supabase-local-ca.crt", cmd: []string{"trust", "extract-compat"}},
+	// openSUSE
+	{dir: "/usr/share/pki/trust/anchors", name: "supabase-local-ca.pem", cmd: []string{"update-ca-certificates"}},
+}
+
+func installTrust(ctx context.Context, caPath string, fsys afero.Fs) error {
+	caPem, err := afero.ReadFile(fsys, caPath)
+	if err != nil {
+		return errors.Errorf("failed to read CA cert: %w", err)
+	}
+	defer printNssHint(caPath)
+	for _, store := range systemTrustStores {
+		if _, err := fsys.Stat(store.dir); err != nil {
+			continue
+		}
+		target := filepath.Join(store.dir, store.name)
+		if err := afero.WriteFile(fsys, target, caPem, 0644); err == nil {
+			err = exec.CommandContext(ctx, store.cmd[0], store.cmd[1:]...).Run()
+		}
+		if err != nil {
+			// Writing to system directories usually requires root
+			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "failed to install local CA into system trust store:", err)
+			fmt.Fprintln(os.Stderr, "Run the following command to trust the local CA:")
+			fmt.Fprintf(os.Stderr, "  sudo cp %s %s && sudo %s\n", caPath, target, strings.Join(store.cmd, " "))
+			return nil
+		}
+		fmt.Fprintln(os.Stderr, "Installed local CA into system trust store:", utils.Bold(target))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "No supported system trust store found. Add the local CA to your trust store manually:", utils.Bold(caPath))
+	return nil
+}
+
+// Firefox and Chromium read trusted CAs from their own NSS database.
+func printNssHint(caPath string) {
+	fmt.Fprintln(os.Stderr, "To trust the local CA in Firefox or Chromium, run:")
+	fmt.Fprintf(os.Stderr, "  certutil -d sql:$HOME/.pki/nssdb -A -t C,, -n %q -i %s\n", utils.LocalCaName, caPath)
+}
@@ -0,0 +1,17 @@
+//go:build !linux
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func installTrust(ctx context.Context, caPath string, fsys afero.Fs) error {
+	fmt.Fprintln(os.Stderr, "Add the local CA to your system trust store manually:", utils.Bold(caPath))
+	return nil
+}
@@ -6,13 +6,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/sarif"
 	"github.com/supabase/cli/pkg/api"
 )
 
@@ -23,6 +26,11 @@ var (
 		"error",
 	}
 
+	AllowedOutputs = []string{
+		utils.OutputJson,
+		utils.OutputSarif,
+	}
+
 	AllowedTypes = []string{
 		"all",
 		"security",
@@ -36,12 +44,12 @@ var (
 type LintLevel int
 
 func toEnum(level string) LintLevel {
-	switch level {
-	case "INFO", "info":
+	switch strings.ToLower(level) {
+	case "info":
 		return 0
-	case "WARN", "warn":
+	case "warn":
 		return 1
-	case "ERROR", "error":
+	case "error":
 		return 2
 	}
 	return -1
@@ -60,7 +68,26 @@ type Lint struct {
 	CacheKey    string           `json:"cache_key"`
 }
 
-func RunLocal(ctx context.Context, advisorType string, level string, failOn string, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+// Object returns the database object a lint refers to, as reported in its metadata.
+func (l Lint) Object() (schema string, name string) {
+	if l.Metadata == nil {
+		return "", ""
+	}
+	var object struct {
+		Schema string `json:"schema"`
+		Name   string `json:"name"`
+	}
+	if err := json.Unmarshal(*l.Metadata, &object); err != nil {
+		return "", ""
+	}
+	return object.Schema, object.Name
+}
+
+func RunLocal(ctx context.Context, advisorType string, level string, failOn string, output string, updateBaseline bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	rules, err := LoadCustomRules(fsys)
+	if err != nil {
+		return err
+	}
 	conn, err := utils.ConnectByConfig(ctx, config, options...)
 	if err != nil {
 		return err
@@ -71,12 +98,25 @@ func RunLocal(ctx context.Context, advisorType string, level string, failOn stri
 	if err != nil {
 		return err
 	}
+	custom, err := QueryCustomLints(ctx, conn, rules)
+	if err != nil {
+		return err
+	}
+	lints = append(lints, custom...)
+	suppressions, err := querySuppressions(ctx, conn)
+	if err != nil {
+		return err
+	}
 
-	filtered := filterLints(lints, advisorType, level)
-	return outputAndCheck(filtered, failOn, os.Stdout)
+	lints = applySuppressions(filterLints(lints, advisorType, "info"), suppressions)
+	return checkBaseline(lints, level, failOn, output, updateBaseline, fsys)
 }
 
-func RunLinked(ctx context.Context, advisorType string, level string, failOn string, projectRef string) error {
+func RunLinked(ctx context.Context, advisorType string, level string, failOn string, output string, updateBaseline bool, projectRef string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	rules, err := LoadCustomRules(fsys)
+	if err != nil {
+		return err
+	}
 	var lints []Lint
 
 	if advisorType == "all" || advisorType == "security" {
@@ -95,8 +135,42 @@ func RunLinked(ctx context.Context, advisorType string, level string, failOn str
 		lints = append(lints, perfLints...)
 	}
 
-	filtered := filterLints(lints, "all", level)
-	return outputAndCheck(filtered, failOn, os.Stdout)
+	// Custom rules can only be evaluated with a direct database connection
+	if len(rules) > 0 {
+		conn, err := utils.ConnectByConfig(ctx, config, options...)
+		if err != nil {
+			return err
+		}
+		defer conn.Close(context.Background())
+		custom, err := QueryCustomLints(ctx, conn, rules)
+		if err != nil {
+			return err
+		}
+		lints = append(lints, filterLints(custom, advisorType, "info")...)
+	}
+	suppressions, err := fetchSuppressions(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+
+	lints = applySuppressions(lints, suppressions)
+	return checkBaseline(lints, level, failOn, output, updateBaseline, fsys)
+}
+
+func checkBaseline(lints []Lint, level string, failOn string, output string, updateBaseline bool, fsys afero.Fs) error {
+	if updateBaseline {
+		return SaveBaseline(lints, fsys)
+	}
+	baseline, err := LoadBaseline(fsys)
+	if err != nil {
+		return err
+	}
+	fresh := baseline.Filter(lints)
+	if ignored := len(lints) - len(fresh); ignored > 0 {
+		fmt.Fprintf(os.Stderr, "Ignored %d findings recorded in %s\n", ignored, utils.Bold(utils.AdvisorsBaselinePath))
+	}
+	filtered := filterLints(fresh, "all", level)
+	return outputAndCheck(filtered, failOn, output, fsys, os.Stdout)
 }
 
 func queryLints(ctx context.Context, conn *pgx.Conn) ([]Lint, error) {
@@ -238,16 +312,23 @@ func matchesType(l Lint, advisorType string) bool {
 	return false
 }
 
-func outputAndCheck(lints []Lint, failOn string, stdout io.Writer) error {
+func outputAndCheck(lints []Lint, failOn string, output string, fsys afero.Fs, stdout io.Writer) error {
+	if output == utils.OutputSarif {
+		if err := printSarif(lints, fsys, stdout); err != nil {
+			return err
+		}
+	}
 	if len(lints) == 0 {
 		fmt.Fprintln(os.Stderr, "No issues found")
 		return nil
 	}
 
-	enc := json.NewEncoder(stdout)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(lints); err != nil {
-		return errors.Errorf("failed to print result json: %w", err)
+	if output != utils.OutputSarif {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(lints); err != nil {
+			return errors.Errorf("failed to print result json: %w", err)
+		}
 	}
 
 	failOnLevel := toEnum(failOn)
@@ -260,3 +341,42 @@ func outputAndCheck(lints []Lint, failOn string, stdout io.Writer) error {
 	}
 	return nil
 }
+
+func printSarif(lints []Lint, fsys afero.Fs, stdout io.Writer) error {
+	locator, err := sarif.NewLocator(fsys)
+	if err != nil {
+		return err
+	}
+	log := sarif.NewLog("supabase db advisors")
+	for _, l := range lints {
+		rule := sarif.Rule{
+			ID:               l.Name,
+			Name:             l.Title,
+			ShortDescription: sarif.NewMessage(l.Title),
+			FullDescription:  sarif.NewMessage(l.Description),
+		}
+		// SARIF requires helpUri to be an absolute url
+		if isAbsoluteURL(l.Remediation) {
+			rule.HelpURI = l.Remediation
+		} else {
+			rule.Help = sarif.NewMessage(l.Remediation)
+		}
+		log.AddRule(rule)
+		result := sarif.Result{
+			RuleID:  l.Name,
+			Level:   sarif.ToLevel(l.Level),
+			Message: sarif.Message{Text: l.Detail},
+		}
+		if len(l.CacheKey) > 0 {
+			result.PartialFingerprints = map[string]string{"cacheKey": l.CacheKey}
+		}
+		result.Locations = []sarif.Location{sarif.OrDefault(locator.Find(l.Object()))}
+		log.AddResult(result)
+	}
+	return log.Write(stdout)
+}
+
+func isAbsoluteURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && parsed.IsAbs() && len(parsed.Host) > 0
+}
@@ -5,14 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/h2non/gock"
 	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/sarif"
 	"github.com/supabase/cli/pkg/api"
 	"github.com/supabase/cli/pkg/pgtest"
 )
@@ -159,7 +162,7 @@ func TestOutputAndCheck(t *testing.T) {
 
 	t.Run("outputs json", func(t *testing.T) {
 		var out bytes.Buffer
-		err := outputAndCheck(lints, "none", &out)
+		err := outputAndCheck(lints, "none", "json", afero.NewMemMapFs(), &out)
 		assert.NoError(t, err)
 		// Validate JSON output
 		var result []Lint
@@ -167,22 +170,88 @@ func TestOutputAndCheck(t *testing.T) {
 		assert.Len(t, result, 2)
 	})
 
+	t.Run("outputs sarif with resolved locations", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		sql := "create schema private;\n\ncreate table public.users (id bigint);\n"
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "20240101000000_init.sql"), []byte(sql), 0644))
+		metadata := json.RawMessage(`{"schema":"public","name":"users","type":"table"}`)
+		withObject := []Lint{{Name: "rls_disabled", Level: "ERROR", Title: "RLS disabled", Detail: "Table public.users has RLS disabled", Metadata: &metadata, CacheKey: "rls_disabled_public_users"}}
+		var out bytes.Buffer
+		err := outputAndCheck(withObject, "none", "sarif", fsys, &out)
+		assert.NoError(t, err)
+		// Validate SARIF output
+		var log sarif.Log
+		require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+		require.Len(t, log.Runs, 1)
+		assert.Equal(t, "rls_disabled", log.Runs[0].Tool.Driver.Rules[0].ID)
+		require.Len(t, log.Runs[0].Results, 1)
+		result := log.Runs[0].Results[0]
+		assert.Equal(t, "error", result.Level)
+		assert.Equal(t, "Table public.users has RLS disabled", result.Message.Text)
+		require.Len(t, result.Locations, 1)
+		assert.Equal(t, "supabase/migrations/20240101000000_init.sql", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 3, result.Locations[0].PhysicalLocation.Region.StartLine)
+	})
+
+	t.Run("outputs sarif help text for relative remediation", func(t *testing.T) {
+		withHelp := []Lint{
+			{Name: "rls_disabled", Level: "ERROR", Title: "RLS disabled", Remediation: "https://supabase.com/docs/guides/database/database-linter"},
+			{Name: "custom", Level: "WARN", Title: "Custom", Remediation: "docs/lints/custom.md"},
+		}
+		var out bytes.Buffer
+		err := outputAndCheck(withHelp, "none", "sarif", afero.NewMemMapFs(), &out)
+		assert.NoError(t, err)
+		// Validate SARIF output
+		var log sarif.Log
+		require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+		rules := log.Runs[0].Tool.Driver.Rules
+		require.Len(t, rules, 2)
+		assert.Equal(t, "custom", rules[0].ID)
+		assert.Empty(t, rules[0].HelpURI)
+		assert.Equal(t, &sarif.Message{Text: "docs/lints/custom.md"}, rules[0].Help)
+		assert.Equal(t, "https://supabase.com/docs/guides/database/database-linter", rules[1].HelpURI)
+		assert.Nil(t, rules[1].Help)
+	})
+
+	t.Run("outputs sarif with default location", func(t *testing.T) {
+		var out bytes.Buffer
+		err := outputAndCheck(lints, "none", "sarif", afero.NewMemMapFs(), &out)
+		assert.NoError(t, err)
+		// Validate SARIF output
+		var log sarif.Log
+		require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+		require.Len(t, log.Runs[0].Results, 2)
+		for _, result := range log.Runs[0].Results {
+			require.Len(t, result.Locations, 1)
+			assert.Equal(t, "supabase/config.toml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		}
+	})
+
+	t.Run("outputs empty sarif log", func(t *testing.T) {
+		var out bytes.Buffer
+		err := outputAndCheck(nil, "none", "sarif", afero.NewMemMapFs(), &out)
+		assert.NoError(t, err)
+		var log sarif.Log
+		require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+		assert.Empty(t, log.Runs[0].Results)
+	})
+
 	t.Run("no issues prints message", func(t *testing.T) {
 		var out bytes.Buffer
-		err := outputAndCheck(nil, "none", &out)
+		err := outputAndCheck(nil, "none", "json", afero.NewMemMapFs(), &out)
 		assert.NoError(t, err)
 		assert.Empty(t, out.String())
 	})
 
 	t.Run("fail-on error triggers on error level", func(t *testing.T) {
 		var out bytes.Buffer
-		err := outputAndCheck(lints, "error", &out)
+		err := outputAndCheck(lints, "error", "json", afero.NewMemMapFs(), &out)
 		assert.ErrorContains(t, err, "fail-on is set to error, non-zero exit")
 	})
 
 	t.Run("fail-on warn triggers on warn level", func(t *testing.T) {
 		var out bytes.Buffer
-		err := outputAndCheck(lints, "warn", &out)
+		err := outputAndCheck(lints, "warn", "json", afero.NewMemMapFs(), &out)
 		assert.ErrorContains(t, err, "fail-on is set to warn, non-zero exit")
 	})
 
@@ -191,7 +260,7 @@ func TestOutputAndCheck(t *testing.T) {
 			{Name: "unindexed_fk", Level: "WARN", Categories: []string{"PERFORMANCE"}},
 		}
 		var out bytes.Buffer
-		err := outputAndCheck(warnOnly, "error", &out)
+		err := outputAndCheck(warnOnly, "error", "json", afero.NewMemMapFs(), &out)
 		assert.NoError(t, err)
 	})
 }
@@ -357,9 +426,11 @@ func TestRunLocalWithDbUrl(t *testing.T) {
 					"rls_disabled_in_public_public_users",
 				},
 			).
-			Query("rollback").Reply("ROLLBACK")
+			Query("rollback").Reply("ROLLBACK").
+			Query(suppressionsSQL).
+			Reply("SELECT 0")
 
-		err := RunLocal(context.Background(), "all", "info", "none", dbConfig, conn.Intercept)
+		err := RunLocal(context.Background(), "all", "info", "none", "json", false, dbConfig, afero.NewMemMapFs(), conn.Intercept)
 		assert.NoError(t, err)
 	})
 
@@ -372,9 +443,11 @@ func TestRunLocalWithDbUrl(t *testing.T) {
 			Reply("SET").
 			Query(querySQL).
 			Reply("SELECT 0").
-			Query("rollback").Reply("ROLLBACK")
+			Query("rollback").Reply("ROLLBACK").
+			Query(suppressionsSQL).
+			Reply("SELECT 0")
 
-		err := RunLocal(context.Background(), "all", "info", "none", dbConfig, conn.Intercept)
+		err := RunLocal(context.Background(), "all", "info", "none", "json", false, dbConfig, afero.NewMemMapFs(), conn.Intercept)
 		assert.NoError(t, err)
 	})
 
@@ -400,9 +473,11 @@ func TestRunLocalWithDbUrl(t *testing.T) {
 					"test_key",
 				},
 			).
-			Query("rollback").Reply("ROLLBACK")
+			Query("rollback").Reply("ROLLBACK").
+			Query(suppressionsSQL).
+			Reply("SELECT 0")
 
-		err := RunLocal(context.Background(), "all", "info", "error", dbConfig, conn.Intercept)
+		err := RunLocal(context.Background(), "all", "info", "error", "json", false, dbConfig, afero.NewMemMapFs(), conn.Intercept)
 		assert.ErrorContains(t, err, "fail-on is set to error")
 	})
 }
@@ -0,0 +1,85 @@
+package advisors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Baseline records pre-existing findings that should not fail future runs.
+type Baseline struct {
+	Lints []BaselineEntry `json:"lints"`
+}
+
+type BaselineEntry struct {
+	Name     string `json:"name"`
+	CacheKey string `json:"cache_key"`
+	// Detail is kept for reviewers and ignored when matching findings.
+	Detail string `json:"detail,omitempty"`
+}
+
+func (e BaselineEntry) key() string {
+	return e.Name + "\x00" + e.CacheKey
+}
+
+func LoadBaseline(fsys afero.Fs) (Baseline, error) {
+	var baseline Baseline
+	data, err := afero.ReadFile(fsys, utils.AdvisorsBaselinePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return baseline, nil
+	} else if err != nil {
+		return baseline, errors.Errorf("failed to read baseline: %w", err)
+	}
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return baseline, errors.Errorf("failed to parse baseline: %w", err)
+	}
+	return baseline, nil
+}
+
+func SaveBaseline(lints []Lint, fsys afero.Fs) error {
+	baseline := Baseline{Lints: []BaselineEntry{}}
+	set := make(map[string]struct{})
+	for _, l := range lints {
+		entry := BaselineEntry{Name: l.Name, CacheKey: l.CacheKey, Detail: l.Detail}
+		if _, exists := set[entry.key()]; exists {
+			continue
+		}
+		set[entry.key()] = struct{}{}
+		baseline.Lints = append(baseline.Lints, entry)
+	}
+	// Sort entries to keep diffs of the committed file minimal
+	sort.Slice(baseline.Lints, func(i, j int) bool {
+		if baseline.Lints[i].Name == baseline.Lints[j].Name {
+			return baseline.Lints[i].CacheKey < baseline.Lints[j].CacheKey
+		}
+		return baseline.Lints[i].Name < baseline.Lints[j].Name
+	})
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := utils.WriteFile(utils.AdvisorsBaselinePath, append(data, '\n'), fsys); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Saved %d findings to %s\n", len(baseline.Lints), utils.Bold(utils.AdvisorsBaselinePath))
+	return nil
+}
+
+// Filter returns findings that are not recorded in the baseline.
+func (b Baseline) Filter(lints []Lint) (fresh []Lint) {
+	set := make(map[string]struct{}, len(b.Lints))
+	for _, e := range b.Lints {
+		set[e.key()] = struct{}{}
+	}
+	for _, l := range lints {
+		if _, exists := set[BaselineEntry{Name: l.Name, CacheKey: l.CacheKey}.key()]; !exists {
+			fresh = append(fresh, l)
+		}
+	}
+	return fresh
+}
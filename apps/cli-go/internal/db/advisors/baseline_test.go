@@ -0,0 +1,66 @@
+package advisors
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestBaseline(t *testing.T) {
+	lints := []Lint{
+		{Name: "rls_disabled_in_public", Level: "ERROR", CacheKey: "rls_disabled_in_public_public_users"},
+		{Name: "unindexed_foreign_keys", Level: "INFO", CacheKey: "unindexed_foreign_keys_public_todos_fk"},
+		{Name: "rls_disabled_in_public", Level: "ERROR", CacheKey: "rls_disabled_in_public_public_users"},
+	}
+
+	t.Run("saves sorted unique entries", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		// Run test
+		require.NoError(t, SaveBaseline(lints, fsys))
+		// Check file contents
+		baseline, err := LoadBaseline(fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, []BaselineEntry{
+			{Name: "rls_disabled_in_public", CacheKey: "rls_disabled_in_public_public_users"},
+			{Name: "unindexed_foreign_keys", CacheKey: "unindexed_foreign_keys_public_todos_fk"},
+		}, baseline.Lints)
+	})
+
+	t.Run("filters known findings", func(t *testing.T) {
+		baseline := Baseline{Lints: []BaselineEntry{
+			{Name: "rls_disabled_in_public", CacheKey: "rls_disabled_in_public_public_users"},
+		}}
+		// Run test
+		fresh := baseline.Filter(append(lints, Lint{Name: "rls_disabled_in_public", CacheKey: "rls_disabled_in_public_public_posts"}))
+		// Check result
+		require.Len(t, fresh, 2)
+		assert.Equal(t, "unindexed_foreign_keys", fresh[0].Name)
+		assert.Equal(t, "rls_disabled_in_public_public_posts", fresh[1].CacheKey)
+	})
+
+	t.Run("ignores missing baseline", func(t *testing.T) {
+		baseline, err := LoadBaseline(afero.NewMemMapFs())
+		assert.NoError(t, err)
+		assert.Len(t, baseline.Filter(lints), 3)
+	})
+
+	t.Run("throws error on malformed baseline", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.AdvisorsBaselinePath, []byte("{"), 0644))
+		_, err := LoadBaseline(fsys)
+		assert.ErrorContains(t, err, "failed to parse baseline")
+	})
+
+	t.Run("fails only on new findings", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, SaveBaseline(lints[:1], fsys))
+		// Run test
+		err := checkBaseline(lints, "info", "error", "json", false, fsys)
+		assert.NoError(t, err)
+		err = checkBaseline(lints, "info", "info", "json", false, fsys)
+		assert.ErrorContains(t, err, "fail-on is set to info, non-zero exit")
+	})
+}
@@ -0,0 +1,137 @@
+package advisors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// CustomRule is a project-local lint loaded from the supabase/lints directory.
+// Each file contains a single select statement returning rows in the same
+// shape as the built-in lints.
+type CustomRule struct {
+	Path string
+	SQL  string
+}
+
+func LoadCustomRules(fsys afero.Fs) ([]CustomRule, error) {
+	paths, err := afero.Glob(fsys, filepath.Join(utils.CustomLintsDir, "*.sql"))
+	if err != nil {
+		return nil, errors.Errorf("failed to glob custom lints: %w", err)
+	}
+	sort.Strings(paths)
+	var rules []CustomRule
+	for _, fp := range paths {
+		contents, err := afero.ReadFile(fsys, fp)
+		if err != nil {
+			return nil, errors.Errorf("failed to read custom lint: %w", err)
+		}
+		sql := strings.TrimRight(strings.TrimSpace(string(contents)), ";")
+		if len(sql) == 0 {
+			continue
+		}
+		rules = append(rules, CustomRule{Path: fp, SQL: sql})
+	}
+	return rules, nil
+}
+
+func wrapCustomRule(sql string) string {
+	return "SELECT to_jsonb(r) FROM (\n" + sql + "\n) r"
+}
+
+// QueryCustomLints runs each custom rule in a transaction that is always rolled
+// back, so rules cannot have side effects on the database.
+func QueryCustomLints(ctx context.Context, conn *pgx.Conn, rules []CustomRule) ([]Lint, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, errors.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(context.Background()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
+	var lints []Lint
+	for _, r := range rules {
+		result, err := queryCustomRule(ctx, tx, r)
+		if err != nil {
+			return nil, err
+		}
+		lints = append(lints, result...)
+	}
+	return lints, nil
+}
+
+func queryCustomRule(ctx context.Context, tx pgx.Tx, rule CustomRule) ([]Lint, error) {
+	rows, err := tx.Query(ctx, wrapCustomRule(rule.SQL))
+	if err != nil {
+		return nil, errors.Errorf("failed to query custom lint %s: %w", rule.Path, err)
+	}
+	defer rows.Close()
+	var lints []Lint
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, errors.Errorf("failed to scan custom lint %s: %w", rule.Path, err)
+		}
+		l, err := parseCustomLint(data)
+		if err != nil {
+			return nil, errors.Errorf("invalid row from custom lint %s: %w", rule.Path, err)
+		}
+		lints = append(lints, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to parse custom lint %s: %w", rule.Path, err)
+	}
+	return lints, nil
+}
+
+func parseCustomLint(data []byte) (Lint, error) {
+	var l Lint
+	if err := json.Unmarshal(data, &l); err != nil {
+		return l, errors.Errorf("failed to unmarshal lint: %w", err)
+	}
+	if len(l.Name) == 0 {
+		return l, errors.New("missing name column")
+	}
+	// Built-in lints use upper case levels, ie. INFO, WARN, ERROR
+	level := strings.ToUpper(strings.TrimSpace(l.Level))
+	if toEnum(level) < 0 {
+		return l, errors.Errorf("unknown level %q: must be one of %s", l.Level, strings.Join(AllowedLevels, ", "))
+	}
+	l.Level = level
+	if len(l.Title) == 0 {
+		l.Title = l.Name
+	}
+	if len(l.Facing) == 0 {
+		l.Facing = "EXTERNAL"
+	}
+	if len(l.CacheKey) == 0 {
+		l.CacheKey = defaultCacheKey(l)
+	}
+	return l, nil
+}
+
+// Mirrors the cache_key format of built-in lints, ie. <name>_<schema>_<object>.
+func defaultCacheKey(l Lint) string {
+	parts := []string{l.Name}
+	schema, name := l.Object()
+	for _, p := range []string{schema, name} {
+		if len(p) > 0 {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, "_")
+}
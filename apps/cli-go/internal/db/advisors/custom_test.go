@@ -0,0 +1,103 @@
+package advisors
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+const customRuleSQL = `select 'public_table_missing_updated_at' as name, 'WARN' as level, array['SECURITY'] as categories`
+
+func TestLoadCustomRules(t *testing.T) {
+	t.Run("loads sql files in order", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.CustomLintsDir, "02_b.sql"), []byte("select 2;\n"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.CustomLintsDir, "01_a.sql"), []byte("select 1"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.CustomLintsDir, "empty.sql"), []byte("\n"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.CustomLintsDir, "README.md"), []byte("docs"), 0644))
+		// Run test
+		rules, err := LoadCustomRules(fsys)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, rules, 2)
+		assert.Equal(t, "select 1", rules[0].SQL)
+		assert.Equal(t, "select 2", rules[1].SQL)
+	})
+
+	t.Run("ignores missing directory", func(t *testing.T) {
+		rules, err := LoadCustomRules(afero.NewMemMapFs())
+		assert.NoError(t, err)
+		assert.Empty(t, rules)
+	})
+}
+
+func TestQueryCustomLints(t *testing.T) {
+	rules := []CustomRule{{Path: "supabase/lints/rule.sql", SQL: customRuleSQL}}
+
+	t.Run("parses custom lint rows", func(t *testing.T) {
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(wrapCustomRule(customRuleSQL)).
+			Reply("SELECT 1", []any{[]byte(`{"name":"public_table_missing_updated_at","level":"warn","categories":["SECURITY"],"detail":"Table public.todos has no updated_at trigger","metadata":{"schema":"public","name":"todos","type":"table"}}`)}).
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		lints, err := QueryCustomLints(context.Background(), conn.MockClient(t), rules)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, lints, 1)
+		assert.Equal(t, "WARN", lints[0].Level)
+		assert.Equal(t, "public_table_missing_updated_at", lints[0].Title)
+		assert.Equal(t, "EXTERNAL", lints[0].Facing)
+		assert.Equal(t, "public_table_missing_updated_at_public_todos", lints[0].CacheKey)
+	})
+
+	t.Run("normalises level case", func(t *testing.T) {
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(wrapCustomRule(customRuleSQL)).
+			Reply("SELECT 1", []any{[]byte(`{"name":"custom","level":"Info"}`)}).
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		lints, err := QueryCustomLints(context.Background(), conn.MockClient(t), rules)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, lints, 1)
+		assert.Equal(t, "INFO", lints[0].Level)
+		// Info lints are kept by the default level filter
+		assert.Len(t, filterLints(lints, "all", "info"), 1)
+	})
+
+	t.Run("throws error on invalid level", func(t *testing.T) {
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(wrapCustomRule(customRuleSQL)).
+			Reply("SELECT 1", []any{[]byte(`{"name":"custom","level":"fatal"}`)}).
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		_, err := QueryCustomLints(context.Background(), conn.MockClient(t), rules)
+		// Check error
+		assert.ErrorContains(t, err, `unknown level "fatal"`)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(wrapCustomRule(customRuleSQL)).
+			ReplyError("42601", "syntax error").
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		_, err := QueryCustomLints(context.Background(), conn.MockClient(t), rules)
+		// Check error
+		assert.ErrorContains(t, err, "custom lint supabase/lints/rule.sql: ERROR: syntax error (SQLSTATE 42601)")
+	})
+}
@@ -0,0 +1,125 @@
+package advisors
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+var (
+	//go:embed templates/suppressions.sql
+	suppressionsSQL string
+
+	ignorePattern = regexp.MustCompile(`@supabase-ignore(?:[ \t]+([A-Za-z0-9_]+(?:[ \t]*,[ \t]*[A-Za-z0-9_]+)*))?`)
+)
+
+// Suppression is parsed from a database comment, for eg.
+//
+//	COMMENT ON TABLE public.logs IS '@supabase-ignore rls_disabled_in_public';
+//
+// An empty Name applies to all objects in Schema. Empty Lints ignores all lints.
+type Suppression struct {
+	Schema string
+	Name   string
+	Lints  []string
+}
+
+func parseSuppression(schema, name, description string) (Suppression, bool) {
+	matches := ignorePattern.FindStringSubmatch(description)
+	if len(matches) == 0 {
+		return Suppression{}, false
+	}
+	s := Suppression{Schema: schema, Name: name}
+	for _, l := range strings.Split(matches[1], ",") {
+		if l = strings.TrimSpace(l); len(l) > 0 {
+			s.Lints = append(s.Lints, l)
+		}
+	}
+	return s, true
+}
+
+func (s Suppression) matches(l Lint) bool {
+	schema, name := l.Object()
+	if schema != s.Schema || (len(s.Name) > 0 && name != s.Name) {
+		return false
+	}
+	if len(s.Lints) == 0 {
+		return true
+	}
+	for _, ignored := range s.Lints {
+		if ignored == l.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func querySuppressions(ctx context.Context, conn *pgx.Conn) ([]Suppression, error) {
+	rows, err := conn.Query(ctx, suppressionsSQL)
+	if err != nil {
+		return nil, errors.Errorf("failed to query suppressions: %w", err)
+	}
+	defer rows.Close()
+	var result []Suppression
+	for rows.Next() {
+		var schema, name, description string
+		if err := rows.Scan(&schema, &name, &description); err != nil {
+			return nil, errors.Errorf("failed to scan suppression: %w", err)
+		}
+		if s, ok := parseSuppression(schema, name, description); ok {
+			result = append(result, s)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to parse suppressions: %w", err)
+	}
+	return result, nil
+}
+
+func fetchSuppressions(ctx context.Context, projectRef string) ([]Suppression, error) {
+	resp, err := utils.GetSupabase().V1RunAQueryWithResponse(ctx, projectRef, api.V1RunAQueryJSONRequestBody{
+		Query: suppressionsSQL,
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to fetch suppressions: %w", err)
+	}
+	if resp.StatusCode() != http.StatusCreated {
+		return nil, errors.Errorf("unexpected suppressions status %d: %s", resp.StatusCode(), string(resp.Body))
+	}
+	var rows []struct {
+		Schema      string `json:"schema"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal(resp.Body, &rows); err != nil {
+		return nil, errors.Errorf("failed to parse suppressions: %w", err)
+	}
+	var result []Suppression
+	for _, r := range rows {
+		if s, ok := parseSuppression(r.Schema, r.Name, r.Description); ok {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func applySuppressions(lints []Lint, suppressions []Suppression) (kept []Lint) {
+OUTER:
+	for _, l := range lints {
+		for _, s := range suppressions {
+			if s.matches(l) {
+				continue OUTER
+			}
+		}
+		kept = append(kept, l)
+	}
+	return kept
+}
@@ -0,0 +1,90 @@
+package advisors
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func newLint(name, schema, object string) Lint {
+	metadata := json.RawMessage(`{"schema":"` + schema + `","name":"` + object + `"}`)
+	return Lint{Name: name, Metadata: &metadata}
+}
+
+func TestParseSuppression(t *testing.T) {
+	t.Run("parses lint names", func(t *testing.T) {
+		s, ok := parseSuppression("public", "logs", "Audit log. @supabase-ignore rls_disabled_in_public, no_primary_key until v2")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"rls_disabled_in_public", "no_primary_key"}, s.Lints)
+	})
+
+	t.Run("ignores all lints without names", func(t *testing.T) {
+		s, ok := parseSuppression("public", "logs", "@supabase-ignore")
+		assert.True(t, ok)
+		assert.Empty(t, s.Lints)
+	})
+
+	t.Run("skips unrelated comments", func(t *testing.T) {
+		_, ok := parseSuppression("public", "logs", "Stores audit logs")
+		assert.False(t, ok)
+	})
+}
+
+func TestApplySuppressions(t *testing.T) {
+	lints := []Lint{
+		newLint("rls_disabled_in_public", "public", "logs"),
+		newLint("no_primary_key", "public", "logs"),
+		newLint("rls_disabled_in_public", "public", "users"),
+		newLint("function_search_path_mutable", "private", "f1"),
+	}
+	suppressions := []Suppression{
+		{Schema: "public", Name: "logs", Lints: []string{"rls_disabled_in_public"}},
+		{Schema: "private"},
+	}
+	// Run test
+	kept := applySuppressions(lints, suppressions)
+	// Check result
+	assert.Equal(t, []Lint{lints[1], lints[2]}, kept)
+}
+
+func TestQuerySuppressions(t *testing.T) {
+	t.Run("queries local database", func(t *testing.T) {
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(suppressionsSQL).
+			Reply("SELECT 1", []any{"public", "logs", "@supabase-ignore rls_disabled_in_public"})
+		// Run test
+		result, err := querySuppressions(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Suppression{{Schema: "public", Name: "logs", Lints: []string{"rls_disabled_in_public"}}}, result)
+	})
+
+	t.Run("fetches from linked project", func(t *testing.T) {
+		projectRef := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + projectRef + "/database/query").
+			Reply(http.StatusCreated).
+			JSON([]map[string]string{{"schema": "private", "name": "", "description": "@supabase-ignore"}})
+		// Run test
+		result, err := fetchSuppressions(context.Background(), projectRef)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "private", result[0].Schema)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
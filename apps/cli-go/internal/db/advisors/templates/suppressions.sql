@@ -0,0 +1,23 @@
+-- Lists comments containing inline suppressions, keyed by schema and object name
+select n.nspname as schema, c.relname as name, d.description
+from pg_catalog.pg_description d
+join pg_catalog.pg_class c on d.classoid = 'pg_catalog.pg_class'::regclass and d.objoid = c.oid and d.objsubid = 0
+join pg_catalog.pg_namespace n on n.oid = c.relnamespace
+where d.description like '%@supabase-ignore%'
+union all
+select n.nspname as schema, p.proname as name, d.description
+from pg_catalog.pg_description d
+join pg_catalog.pg_proc p on d.classoid = 'pg_catalog.pg_proc'::regclass and d.objoid = p.oid
+join pg_catalog.pg_namespace n on n.oid = p.pronamespace
+where d.description like '%@supabase-ignore%'
+union all
+select n.nspname as schema, e.extname as name, d.description
+from pg_catalog.pg_description d
+join pg_catalog.pg_extension e on d.classoid = 'pg_catalog.pg_extension'::regclass and d.objoid = e.oid
+join pg_catalog.pg_namespace n on n.oid = e.extnamespace
+where d.description like '%@supabase-ignore%'
+union all
+select n.nspname as schema, '' as name, d.description
+from pg_catalog.pg_description d
+join pg_catalog.pg_namespace n on d.classoid = 'pg_catalog.pg_namespace'::regclass and d.objoid = n.oid
+where d.description like '%@supabase-ignore%'
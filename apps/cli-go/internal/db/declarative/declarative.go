@@ -288,7 +288,7 @@ func getGenerateBaselineCatalogRef(ctx context.Context, noCache bool, fsys afero
 			return generateBaselineCatalogRef{ref: cachePath}, nil
 		}
 	}
-	shadowID, config, err := createShadow(ctx)
+	shadowID, config, err := createShadow(ctx, fsys)
 	if err != nil {
 		return generateBaselineCatalogRef{}, err
 	}
@@ -354,7 +354,7 @@ func getMigrationsCatalogRef(ctx context.Context, noCache bool, fsys afero.Fs, p
 			return cachePath, nil
 		}
 	}
-	shadow, config, err := createShadow(ctx)
+	shadow, config, err := createShadow(ctx, fsys)
 	if err != nil {
 		return "", err
 	}
@@ -387,7 +387,7 @@ func getDeclarativeCatalogRef(ctx context.Context, noCache bool, fsys afero.Fs,
 			return path, nil
 		}
 	}
-	shadow, config, err := createShadow(ctx)
+	shadow, config, err := createShadow(ctx, fsys)
 	if err != nil {
 		return "", err
 	}
@@ -421,9 +421,9 @@ func writeDeclarativeCatalogFromConfig(ctx context.Context, config pgconn.Config
 
 // createShadow provisions and health-checks the temporary Postgres container
 // used by declarative conversion and diff operations.
-func createShadow(ctx context.Context) (string, pgconn.Config, error) {
+func createShadow(ctx context.Context, fsys afero.Fs) (string, pgconn.Config, error) {
 	fmt.Fprintln(os.Stderr, "Creating shadow database...")
-	shadow, err := diff.CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort)
+	shadow, err := diff.CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort, fsys)
 	if err != nil {
 		return "", pgconn.Config{}, err
 	}
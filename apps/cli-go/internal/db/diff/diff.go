@@ -114,12 +114,17 @@ func findDropStatements(out string) []string {
 	return drops
 }
 
-func CreateShadowDatabase(ctx context.Context, port uint16) (string, error) {
+func CreateShadowDatabase(ctx context.Context, port uint16, fsys afero.Fs) (string, error) {
+	// Extensions are mounted from the same directories as the local database
+	if err := start.DownloadExtensions(ctx, fsys); err != nil {
+		return "", err
+	}
 	// Disable background workers in shadow database
 	config := start.NewContainerConfig("-c", "max_worker_processes=0")
 	hostPort := strconv.FormatUint(uint64(port), 10)
 	hostConfig := container.HostConfig{
 		PortBindings: nat.PortMap{"5432/tcp": []nat.PortBinding{{HostPort: hostPort}}},
+		Binds:        start.ExtensionBinds(),
 		AutoRemove:   true,
 	}
 	networkingConfig := network.NetworkingConfig{}
@@ -163,7 +168,7 @@ func MigrateShadowDatabase(ctx context.Context, container string, fsys afero.Fs,
 
 func DiffDatabase(ctx context.Context, schema []string, config pgconn.Config, w io.Writer, fsys afero.Fs, differ DiffFunc, usePgDelta bool, options ...func(*pgx.ConnConfig)) (string, error) {
 	fmt.Fprintln(w, "Creating shadow database...")
-	shadow, err := CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort)
+	shadow, err := CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort, fsys)
 	if err != nil {
 		return "", err
 	}
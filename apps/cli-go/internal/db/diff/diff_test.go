@@ -24,6 +24,7 @@ import (
 	"github.com/supabase/cli/internal/testing/helper"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
 	"github.com/supabase/cli/pkg/migration"
 	"github.com/supabase/cli/pkg/pgtest"
 )
@@ -113,6 +114,28 @@ func TestRun(t *testing.T) {
 	})
 }
 
+func TestCreateShadowDatabase(t *testing.T) {
+	utils.Config.Db.Extensions = map[string]config.DbExtension{
+		"pair": {Pgxn: "pair", Version: "0.1.7"},
+	}
+	defer func() { utils.Config.Db.Extensions = nil }()
+
+	t.Run("downloads extensions before mounting them", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.pgxn.org").
+			Get("/dist/pair/0.1.7/pair-0.1.7.zip").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := CreateShadowDatabase(context.Background(), 54320, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Error status 503")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
 func TestMigrateShadow(t *testing.T) {
 	utils.Config.Db.MajorVersion = 14
 
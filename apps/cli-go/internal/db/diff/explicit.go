@@ -95,7 +95,7 @@ func resolveMigrationsCatalogRef(ctx context.Context, fsys afero.Fs, options ...
 	} else if ok {
 		return cachePath, nil
 	}
-	shadow, err := CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort)
+	shadow, err := CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort, fsys)
 	if err != nil {
 		return "", err
 	}
@@ -13,7 +13,9 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/advisors"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/sarif"
 	"github.com/supabase/cli/pkg/migration"
 )
 
@@ -24,6 +26,10 @@ var (
 		"warning",
 		"error",
 	}
+	AllowedOutputs = []string{
+		utils.OutputJson,
+		utils.OutputSarif,
+	}
 	//go:embed templates/check.sql
 	checkSchemaScript string
 )
@@ -39,8 +45,12 @@ func toEnum(level string) LintLevel {
 	return -1
 }
 
-func Run(ctx context.Context, schema []string, level string, failOn string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func Run(ctx context.Context, schema []string, level string, failOn string, output string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	// Sanity checks.
+	rules, err := advisors.LoadCustomRules(fsys)
+	if err != nil {
+		return err
+	}
 	conn, err := utils.ConnectByConfig(ctx, config, options...)
 	if err != nil {
 		return err
@@ -51,15 +61,27 @@ func Run(ctx context.Context, schema []string, level string, failOn string, conf
 	if err != nil {
 		return err
 	}
+	// Run project-local rules
+	custom, err := advisors.QueryCustomLints(ctx, conn, rules)
+	if err != nil {
+		return err
+	}
+	result = append(result, toResult(custom)...)
 	if len(result) == 0 {
 		fmt.Fprintln(os.Stderr, "\nNo schema errors found")
-		return nil
+		if output != utils.OutputSarif {
+			return nil
+		}
 	}
 
 	// Apply filtering based on the minimum level
 	minLevel := toEnum(level)
 	filtered := filterResult(result, minLevel)
-	err = printResultJSON(filtered, os.Stdout)
+	if output == utils.OutputSarif {
+		err = printResultSarif(filtered, fsys, os.Stdout)
+	} else {
+		err = printResultJSON(filtered, os.Stdout)
+	}
 	if err != nil {
 		return err
 	}
@@ -92,6 +114,40 @@ func filterResult(result []Result, minLevel LintLevel) (filtered []Result) {
 	return filtered
 }
 
+// Custom rules report database objects rather than functions, so each lint is
+// keyed by the object it refers to, falling back to the rule name.
+func toResult(lints []advisors.Lint) (result []Result) {
+	for _, l := range lints {
+		r := Result{Function: l.Name}
+		if schema, name := l.Object(); len(schema) > 0 && len(name) > 0 {
+			r.Function = schema + "." + name
+		} else if len(name) > 0 {
+			r.Function = name
+		}
+		r.Issues = append(r.Issues, Issue{
+			Level:   toLintLevel(l.Level),
+			Message: l.Detail,
+			Hint:    l.Remediation,
+			Detail:  l.Description,
+			Rule:    l.Name,
+		})
+		result = append(result, r)
+	}
+	return result
+}
+
+// toLintLevel maps advisor levels to lint levels. Lint has no info level, so
+// info findings are reported as warnings instead of being filtered out.
+func toLintLevel(level string) string {
+	switch strings.ToLower(level) {
+	case "info", "warn":
+		return "warning"
+	case "error":
+		return "error"
+	}
+	return strings.ToLower(level)
+}
+
 func printResultJSON(result []Result, stdout io.Writer) error {
 	if len(result) == 0 {
 		return nil
@@ -105,6 +161,42 @@ func printResultJSON(result []Result, stdout io.Writer) error {
 	return nil
 }
 
+func printResultSarif(result []Result, fsys afero.Fs, stdout io.Writer) error {
+	locator, err := sarif.NewLocator(fsys)
+	if err != nil {
+		return err
+	}
+	log := sarif.NewLog("supabase db lint")
+	for _, r := range result {
+		location := sarif.OrDefault(locator.FindQualified(r.Function))
+		for _, issue := range r.Issues {
+			rule := sarif.Rule{ID: issue.Rule, Name: issue.Rule, FullDescription: sarif.NewMessage(issue.Detail)}
+			if len(rule.ID) == 0 {
+				rule = sarif.Rule{ID: "plpgsql_check", Name: "plpgsql_check", ShortDescription: sarif.NewMessage("Function body check")}
+				if len(issue.SQLState) > 0 {
+					rule.ID += "/" + issue.SQLState
+				}
+			}
+			log.AddRule(rule)
+			message := fmt.Sprintf("%s: %s", r.Function, issue.Message)
+			if issue.Statement != nil && len(issue.Statement.LineNumber) > 0 {
+				message += fmt.Sprintf(" (line %s of function body)", issue.Statement.LineNumber)
+			}
+			if len(issue.Hint) > 0 {
+				message += "\n" + issue.Hint
+			}
+			finding := sarif.Result{
+				RuleID:    rule.ID,
+				Level:     sarif.ToLevel(issue.Level),
+				Message:   sarif.Message{Text: message},
+				Locations: []sarif.Location{location},
+			}
+			log.AddResult(finding)
+		}
+	}
+	return log.Write(stdout)
+}
+
 func LintDatabase(ctx context.Context, conn *pgx.Conn, schema []string) ([]Result, error) {
 	tx, err := conn.Begin(ctx)
 	if err != nil {
@@ -181,6 +273,8 @@ type Issue struct {
 	Detail    string     `json:"detail,omitempty"`
 	Context   string     `json:"context,omitempty"`
 	SQLState  string     `json:"sqlState,omitempty"`
+	// Rule is set for findings reported by project-local lint rules.
+	Rule string `json:"rule,omitempty"`
 }
 
 type Result struct {
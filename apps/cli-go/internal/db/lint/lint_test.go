@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/docker/docker/api/types/container"
@@ -14,6 +15,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/advisors"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/pgtest"
@@ -65,7 +67,7 @@ func TestLintCommand(t *testing.T) {
 		Reply("SELECT 1", []any{"f1", string(data)}).
 		Query("rollback").Reply("ROLLBACK")
 	// Run test
-	err = Run(context.Background(), []string{"public"}, "warning", "none", dbConfig, fsys, conn.Intercept)
+	err = Run(context.Background(), []string{"public"}, "warning", "none", "json", dbConfig, fsys, conn.Intercept)
 	// Check error
 	assert.NoError(t, err)
 	assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -229,6 +231,15 @@ func TestPrintResult(t *testing.T) {
 		assert.ElementsMatch(t, result, actual)
 	})
 
+	t.Run("reports custom info rule as warning", func(t *testing.T) {
+		custom := toResult([]advisors.Lint{{Name: "naming", Level: "INFO", Detail: "Table name is plural"}})
+		// Run test
+		filtered := filterResult(custom, toEnum("warning"))
+		// Validate output
+		require.Len(t, filtered, 1)
+		assert.Equal(t, "warning", filtered[0].Issues[0].Level)
+	})
+
 	t.Run("filters error level", func(t *testing.T) {
 		// Run test
 		var out bytes.Buffer
@@ -256,7 +267,7 @@ func TestPrintResult(t *testing.T) {
 			Reply("SELECT 1", []any{"f1", `{"function":"22751","issues":[{"level":"warning","message":"test warning"}]}`}).
 			Query("rollback").Reply("ROLLBACK")
 		// Run test
-		err := Run(context.Background(), []string{"public"}, "warning", "warning", dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), []string{"public"}, "warning", "warning", "json", dbConfig, fsys, conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, "fail-on is set to warning, non-zero exit")
 	})
@@ -274,7 +285,31 @@ func TestPrintResult(t *testing.T) {
 			Reply("SELECT 1", []any{"f1", `{"function":"22751","issues":[{"level":"error","message":"test error"}]}`}).
 			Query("rollback").Reply("ROLLBACK")
 		// Run test
-		err := Run(context.Background(), []string{"public"}, "warning", "error", dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), []string{"public"}, "warning", "error", "json", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "fail-on is set to error, non-zero exit")
+	})
+
+	t.Run("exits with non-zero status on custom rule", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		rule := "select 'missing_rls' as name, 'ERROR' as level"
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.CustomLintsDir, "rls.sql"), []byte(rule+";"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(ENABLE_PGSQL_CHECK).
+			Reply("CREATE EXTENSION").
+			Query(checkSchemaScript, "public").
+			Reply("SELECT 0").
+			Query("rollback").Reply("ROLLBACK").
+			Query("begin").Reply("BEGIN").
+			Query("SELECT to_jsonb(r) FROM (\n"+rule+"\n) r").
+			Reply("SELECT 1", []any{[]byte(`{"name":"missing_rls","level":"ERROR","detail":"Table public.todos has RLS disabled","metadata":{"schema":"public","name":"todos"}}`)}).
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		err := Run(context.Background(), []string{"public"}, "warning", "error", "json", dbConfig, fsys, conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, "fail-on is set to error, non-zero exit")
 	})
@@ -292,7 +327,7 @@ func TestPrintResult(t *testing.T) {
 			Reply("SELECT 1", []any{"f1", `{"function":"22751","issues":[{"level":"error","message":"test error"}]}`}).
 			Query("rollback").Reply("ROLLBACK")
 		// Run test
-		err := Run(context.Background(), []string{"public"}, "warning", "none", dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), []string{"public"}, "warning", "none", "json", dbConfig, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 	})
@@ -80,7 +80,7 @@ func Run(ctx context.Context, schema []string, config pgconn.Config, name string
 // timestamped migration files.
 func pullDeclarativePgDelta(ctx context.Context, schema []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	fmt.Fprintln(os.Stderr, "Preparing declarative schema export using pg-delta...")
-	shadow, err := diff.CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort)
+	shadow, err := diff.CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort, fsys)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,179 @@
+package start
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+// Custom extensions are mounted here and copied into the Postgres install
+// directories before the server starts.
+const containerExtensionsDir = "/usr/local/share/supabase/extensions"
+
+var pgxnApi = fetcher.NewFetcher(
+	"https://api.pgxn.org",
+	fetcher.WithExpectedStatus(http.StatusOK),
+)
+
+func extensionNames() []string {
+	names := make([]string, 0, len(utils.Config.Db.Extensions))
+	for name := range utils.Config.Db.Extensions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func hostExtensionDir(name string, ext config.DbExtension) string {
+	dir := ext.Path
+	if len(ext.Pgxn) > 0 {
+		dir = filepath.Join(utils.TempDir, "extensions", name)
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(utils.CurrentDirAbs, dir)
+	}
+	return dir
+}
+
+// ExtensionBinds returns read-only mounts for extensions declared under [db.extensions].
+func ExtensionBinds() []string {
+	var binds []string
+	for _, name := range extensionNames() {
+		dir := hostExtensionDir(name, utils.Config.Db.Extensions[name])
+		binds = append(binds, fmt.Sprintf("%s:%s:ro", utils.ToDockerPath(dir), path.Join(containerExtensionsDir, name)))
+	}
+	return binds
+}
+
+func installExtensionsScript() string {
+	if len(utils.Config.Db.Extensions) == 0 {
+		return ""
+	}
+	return `find ` + containerExtensionsDir + ` \( -name '*.control' -o -name '*.sql' \) -exec cp -t "$(pg_config --sharedir)/extension" {} + && \
+find ` + containerExtensionsDir + ` -name '*.so' -exec cp -t "$(pg_config --pkglibdir)" {} + && \
+`
+}
+
+// DownloadExtensions fetches PGXN distributions declared under [db.extensions]
+// into the temp directory, skipping those that are already cached.
+func DownloadExtensions(ctx context.Context, fsys afero.Fs) error {
+	for _, name := range extensionNames() {
+		ext := utils.Config.Db.Extensions[name]
+		if len(ext.Pgxn) == 0 {
+			continue
+		}
+		dir := filepath.Join(utils.TempDir, "extensions", name)
+		marker := filepath.Join(dir, ".pgxn")
+		if data, err := afero.ReadFile(fsys, marker); err == nil {
+			dist, version, _ := strings.Cut(string(data), "@")
+			if dist == ext.Pgxn && (len(ext.Version) == 0 || version == ext.Version) {
+				continue
+			}
+		}
+		version := ext.Version
+		if len(version) == 0 {
+			var err error
+			if version, err = getLatestPgxnVersion(ctx, ext.Pgxn); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Downloading extension %s from PGXN: %s@%s\n", name, ext.Pgxn, version)
+		if err := downloadPgxn(ctx, name, ext.Pgxn, version, dir, fsys); err != nil {
+			return err
+		}
+		if err := utils.WriteFile(marker, []byte(ext.Pgxn+"@"+version), fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type pgxnDist struct {
+	Releases map[string][]struct {
+		Version string `json:"version"`
+	} `json:"releases"`
+}
+
+func getLatestPgxnVersion(ctx context.Context, dist string) (string, error) {
+	resp, err := pgxnApi.Send(ctx, http.MethodGet, "/dist/"+strings.ToLower(dist)+".json", nil)
+	if err != nil {
+		return "", errors.Errorf("failed to get PGXN distribution %s: %w", dist, err)
+	}
+	body, err := fetcher.ParseJSON[pgxnDist](resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if stable := body.Releases["stable"]; len(stable) > 0 {
+		return stable[0].Version, nil
+	}
+	return "", errors.Errorf("no stable release found for PGXN distribution: %s", dist)
+}
+
+func downloadPgxn(ctx context.Context, name, dist, version, dir string, fsys afero.Fs) error {
+	dist = strings.ToLower(dist)
+	resp, err := pgxnApi.Send(ctx, http.MethodGet, fmt.Sprintf("/dist/%[1]s/%[2]s/%[1]s-%[2]s.zip", dist, version), nil)
+	if err != nil {
+		return errors.Errorf("failed to download PGXN distribution %s: %w", dist, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Errorf("failed to read PGXN distribution: %w", err)
+	}
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return errors.Errorf("failed to unzip PGXN distribution: %w", err)
+	}
+	// Only prebuilt files can be installed since the image has no build toolchain
+	var files []*zip.File
+	for _, f := range r.File {
+		base := path.Base(f.Name)
+		switch {
+		case path.Ext(base) == ".c":
+			return errors.Errorf("PGXN distribution %s contains C sources that must be compiled. Build the extension and set db.extensions.%s.path instead.", dist, name)
+		case path.Ext(base) == ".control", path.Ext(base) == ".sql" && strings.Contains(base, "--"):
+			files = append(files, f)
+		}
+	}
+	if !slices.ContainsFunc(files, func(f *zip.File) bool {
+		return path.Base(f.Name) == name+".control"
+	}) {
+		return errors.Errorf("PGXN distribution %s does not contain %s.control", dist, name)
+	}
+	if err := fsys.RemoveAll(dir); err != nil {
+		return errors.Errorf("failed to remove cached extension: %w", err)
+	}
+	for _, f := range files {
+		if err := extractFile(f, filepath.Join(dir, path.Base(f.Name)), fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractFile(f *zip.File, dst string, fsys afero.Fs) error {
+	rc, err := f.Open()
+	if err != nil {
+		return errors.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return errors.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	return utils.WriteFile(dst, data, fsys)
+}
@@ -0,0 +1,138 @@
+package start
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func newZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestExtensionBinds(t *testing.T) {
+	utils.CurrentDirAbs = "/project"
+	utils.Config.Db.Extensions = map[string]config.DbExtension{
+		"pair":   {Pgxn: "pair"},
+		"my_ext": {Path: "supabase/extensions/my_ext"},
+	}
+	defer func() { utils.Config.Db.Extensions = nil }()
+	// Run test
+	binds := ExtensionBinds()
+	// Check output
+	assert.Equal(t, []string{
+		"/project/supabase/extensions/my_ext:/usr/local/share/supabase/extensions/my_ext:ro",
+		"/project/supabase/.temp/extensions/pair:/usr/local/share/supabase/extensions/pair:ro",
+	}, binds)
+	assert.Contains(t, NewContainerConfig().Entrypoint[2], `-exec cp -t "$(pg_config --sharedir)/extension" {} +`)
+}
+
+func TestDownloadExtensions(t *testing.T) {
+	dir := filepath.Join(utils.TempDir, "extensions", "pair")
+	utils.Config.Db.Extensions = map[string]config.DbExtension{
+		"pair": {Pgxn: "pair"},
+	}
+	defer func() { utils.Config.Db.Extensions = nil }()
+
+	t.Run("downloads latest stable release", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.pgxn.org").
+			Get("/dist/pair.json").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"releases": map[string]any{
+				"stable": []map[string]string{{"version": "0.1.7"}, {"version": "0.1.6"}},
+			}})
+		gock.New("https://api.pgxn.org").
+			Get("/dist/pair/0.1.7/pair-0.1.7.zip").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(newZip(t, map[string]string{
+				"pair-0.1.7/pair.control":        "default_version = '0.1.7'",
+				"pair-0.1.7/sql/pair--0.1.7.sql": "CREATE TYPE pair;",
+				"pair-0.1.7/sql/pair.sql":        "-- not installable",
+				"pair-0.1.7/test/sql/base.sql":   "SELECT 1;",
+				"pair-0.1.7/doc/pair.md":         "# pair",
+				"pair-0.1.7/META.json":           "{}",
+			})))
+		// Run test
+		err := DownloadExtensions(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		files, err := afero.ReadDir(fsys, dir)
+		require.NoError(t, err)
+		var names []string
+		for _, f := range files {
+			names = append(names, f.Name())
+		}
+		assert.ElementsMatch(t, []string{".pgxn", "pair.control", "pair--0.1.7.sql"}, names)
+	})
+
+	t.Run("skips cached distribution", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, ".pgxn"), []byte("pair@0.1.7"), 0644))
+		// Run test
+		err := DownloadExtensions(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on C sources", func(t *testing.T) {
+		utils.Config.Db.Extensions["pair"] = config.DbExtension{Pgxn: "pair", Version: "0.1.7"}
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.pgxn.org").
+			Get("/dist/pair/0.1.7/pair-0.1.7.zip").
+			Reply(http.StatusOK).
+			Body(bytes.NewReader(newZip(t, map[string]string{
+				"pair-0.1.7/pair.control": "",
+				"pair-0.1.7/src/pair.c":   "",
+			})))
+		// Run test
+		err := DownloadExtensions(context.Background(), fsys)
+		// Check error
+		assert.ErrorContains(t, err, "PGXN distribution pair contains C sources that must be compiled")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing release", func(t *testing.T) {
+		utils.Config.Db.Extensions["pair"] = config.DbExtension{Pgxn: "pair"}
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("https://api.pgxn.org").
+			Get("/dist/pair.json").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := DownloadExtensions(context.Background(), fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to get PGXN distribution pair: Error status 404")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -89,7 +89,7 @@ func NewContainerConfig(args ...string) container.Config {
 			Retries:  3,
 		},
 		Entrypoint: []string{"sh", "-c", `
-cat <<'EOF' > /etc/postgresql.schema.sql && \
+` + installExtensionsScript() + `cat <<'EOF' > /etc/postgresql.schema.sql && \
 cat <<'EOF' > /etc/postgresql-custom/pgsodium_root.key && \
 cat <<'EOF' >> /etc/postgresql/postgresql.conf && \
 docker-entrypoint.sh postgres -D /etc/postgresql ` + strings.Join(args, " ") + `
@@ -104,7 +104,7 @@ EOF`},
 	}
 	if utils.Config.Db.MajorVersion <= 14 {
 		config.Entrypoint = []string{"sh", "-c", `
-cat <<'EOF' > /docker-entrypoint-initdb.d/supabase_schema.sql && \
+` + installExtensionsScript() + `cat <<'EOF' > /docker-entrypoint-initdb.d/supabase_schema.sql && \
 cat <<'EOF' >> /etc/postgresql/postgresql.conf && \
 docker-entrypoint.sh postgres -D /etc/postgresql ` + strings.Join(args, " ") + `
 ` + _supabaseSchema + `
@@ -120,9 +120,10 @@ func NewHostConfig() container.HostConfig {
 	hostConfig := container.HostConfig{
 		PortBindings:  nat.PortMap{"5432/tcp": []nat.PortBinding{{HostPort: hostPort}}},
 		RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
-		Binds: []string{
+		Binds: append([]string{
 			utils.DbId + ":/var/lib/postgresql/data",
-		},
+		}, ExtensionBinds()...),
+		Resources: utils.ToDockerResources(utils.Config.Db.Resources),
 	}
 	if utils.Config.Db.MajorVersion <= 14 {
 		hostConfig.Tmpfs = map[string]string{"/docker-entrypoint-initdb.d": ""}
@@ -131,6 +132,9 @@ func NewHostConfig() container.HostConfig {
 }
 
 func StartDatabase(ctx context.Context, fromBackup string, fsys afero.Fs, w io.Writer, options ...func(*pgx.ConnConfig)) error {
+	if err := DownloadExtensions(ctx, fsys); err != nil {
+		return err
+	}
 	config := NewContainerConfig()
 	hostConfig := NewHostConfig()
 	networkingConfig := network.NetworkingConfig{
@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,7 +18,7 @@ import (
 	"github.com/supabase/cli/pkg/function"
 )
 
-func Run(ctx context.Context, slugs []string, useDocker bool, noVerifyJWT *bool, importMapPath string, maxJobs uint, prune bool, fsys afero.Fs) error {
+func Run(ctx context.Context, slugs []string, useDocker bool, noVerifyJWT *bool, importMapPath string, maxJobs uint, prune, force, dryRun bool, fsys afero.Fs) error {
 	// Load function config and project id
 	if err := flags.LoadConfig(fsys); err != nil {
 		return err
@@ -60,12 +61,26 @@ func Run(ctx context.Context, slugs []string, useDocker bool, noVerifyJWT *bool,
 			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Docker is not running")
 		}
 	}
-	api := function.NewEdgeRuntimeAPI(flags.ProjectRef, *utils.GetSupabase(), opt)
-	if err := api.Deploy(ctx, functionConfig, afero.NewIOFS(fsys)); errors.Is(err, function.ErrNoDeploy) {
+	hashes := loadSourceHashes(fsys)
+	api := function.NewEdgeRuntimeAPI(flags.ProjectRef, *utils.GetSupabase(), opt,
+		function.WithSourceHashes(hashes, utils.Config.EdgeRuntime.DenoVersion, afero.NewIOFS(fsys)),
+		function.WithForce(force),
+		function.WithDryRun(dryRun),
+	)
+	err = api.Deploy(ctx, functionConfig, afero.NewIOFS(fsys))
+	// Save hashes of functions deployed before any error
+	if !dryRun {
+		if err := saveSourceHashes(hashes, fsys); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	if errors.Is(err, function.ErrNoDeploy) {
 		fmt.Fprintln(os.Stderr, err)
 		return nil
 	} else if err != nil {
 		return err
+	} else if dryRun {
+		return nil
 	}
 	fmt.Printf("Deployed Functions on project %s: %s\n", utils.Aqua(flags.ProjectRef), strings.Join(slugs, ", "))
 	url := fmt.Sprintf("%s/project/%v/functions", utils.GetSupabaseDashboardURL(), flags.ProjectRef)
@@ -76,6 +91,32 @@ func Run(ctx context.Context, slugs []string, useDocker bool, noVerifyJWT *bool,
 	return pruneFunctions(ctx, functionConfig)
 }
 
+// loadSourceHashes reads the source hashes of previously deployed functions.
+// A missing or corrupted file is treated as empty so that all functions are bundled.
+func loadSourceHashes(fsys afero.Fs) function.SourceHashes {
+	hashes := function.SourceHashes{}
+	data, err := afero.ReadFile(fsys, utils.FunctionHashesPath)
+	if err != nil {
+		return hashes
+	}
+	if err := json.Unmarshal(data, &hashes); err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), "failed to parse function hashes:", err)
+		return function.SourceHashes{}
+	}
+	return hashes
+}
+
+func saveSourceHashes(hashes function.SourceHashes, fsys afero.Fs) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(hashes)
+	if err != nil {
+		return errors.Errorf("failed to encode function hashes: %w", err)
+	}
+	return utils.WriteFile(utils.FunctionHashesPath, data, fsys)
+}
+
 func GetFunctionSlugs(fsys afero.Fs) (slugs []string, err error) {
 	pattern := filepath.Join(utils.FunctionsDir, "*", "index.ts")
 	paths, err := afero.Glob(fsys, pattern)
@@ -0,0 +1,159 @@
+package invoke
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/gen/bearerjwt"
+	"github.com/supabase/cli/internal/status"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/utils/tenant"
+	"github.com/supabase/cli/pkg/config"
+)
+
+type Options struct {
+	Method  string
+	Data    string
+	Headers []string
+	Role    string
+	Subject string
+}
+
+// Run calls a Function served locally, or deployed to projectRef if not empty,
+// streaming the response body to w and the status, headers and timing to stderr.
+func Run(ctx context.Context, slug, projectRef string, opts Options, w io.Writer, fsys afero.Fs) error {
+	body, err := readBody(opts.Data, fsys)
+	if err != nil {
+		return err
+	}
+	var client *http.Client
+	var endpoint, token string
+	if len(projectRef) == 0 {
+		if err := flags.LoadConfig(fsys); err != nil {
+			return err
+		}
+		if token, err = mintLocalToken(opts.Role, opts.Subject); err != nil {
+			return err
+		}
+		client = status.NewKongClient()
+		endpoint = utils.GetApiUrl("/functions/v1/" + slug)
+	} else {
+		if token, err = getRemoteKey(ctx, projectRef, opts.Role, opts.Subject); err != nil {
+			return err
+		}
+		client = http.DefaultClient
+		endpoint = fmt.Sprintf("https://%s/functions/v1/%s", utils.GetSupabaseHost(projectRef), slug)
+	}
+	req, err := http.NewRequestWithContext(ctx, opts.Method, endpoint, body)
+	if err != nil {
+		return errors.Errorf("failed to initialise request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("apikey", token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, h := range opts.Headers {
+		k, v, found := strings.Cut(h, ":")
+		if !found {
+			return errors.Errorf("invalid header format, expected key:value: %s", h)
+		}
+		req.Header.Set(strings.TrimSpace(k), strings.TrimSpace(v))
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		if len(projectRef) == 0 {
+			utils.CmdSuggestion = fmt.Sprintf("Make sure your local development setup is running: %s", utils.Aqua("supabase start"))
+		}
+		return errors.Errorf("failed to invoke function: %w", err)
+	}
+	defer resp.Body.Close()
+	firstByte := time.Since(start)
+	printHeaders(os.Stderr, resp)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Errorf("failed to read response: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "\nCompleted in %s (first byte after %s)\n", time.Since(start).Round(time.Millisecond), firstByte.Round(time.Millisecond))
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("function returned error status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// readBody returns the request body from data, or from a file if prefixed with @.
+func readBody(data string, fsys afero.Fs) (io.Reader, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	path, isFile := strings.CutPrefix(data, "@")
+	if !isFile {
+		return strings.NewReader(data), nil
+	}
+	contents, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return nil, errors.Errorf("failed to read request body: %w", err)
+	}
+	return bytes.NewReader(contents), nil
+}
+
+func mintLocalToken(role, sub string) (string, error) {
+	now := time.Now()
+	claims := config.CustomClaims{
+		Issuer: utils.Config.Auth.JwtIssuer,
+		Role:   role,
+		// Set is_anonymous = true for authenticated role without explicit user ID
+		IsAnon: strings.EqualFold(role, "authenticated") && len(sub) == 0,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   sub,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+	return bearerjwt.NewLocalToken(claims)
+}
+
+// getRemoteKey returns the project API key for role, since remote JWTs cannot
+// be minted without the project's signing key.
+func getRemoteKey(ctx context.Context, projectRef, role, sub string) (string, error) {
+	if len(sub) > 0 {
+		return "", errors.New("--sub is only supported for local functions. Use --local to invoke a served function.")
+	}
+	keys, err := tenant.GetApiKeys(ctx, projectRef)
+	if err != nil {
+		return "", err
+	}
+	switch role {
+	case "anon":
+		return keys.Anon, nil
+	case "service_role":
+		return keys.ServiceRole, nil
+	}
+	return "", errors.Errorf("invalid role for deployed functions: %s (must be anon or service_role)", role)
+}
+
+func printHeaders(w io.Writer, resp *http.Response) {
+	fmt.Fprintln(w, resp.Proto, resp.Status)
+	keys := make([]string, 0, len(resp.Header))
+	for k := range resp.Header {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		for _, v := range resp.Header[k] {
+			fmt.Fprintf(w, "%s: %s\n", k, v)
+		}
+	}
+	fmt.Fprintln(w)
+}
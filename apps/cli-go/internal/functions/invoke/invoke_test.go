@@ -0,0 +1,116 @@
+package invoke
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/oapi-codegen/nullable"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestInvokeLocal(t *testing.T) {
+	t.Run("invokes served function with minted JWT", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, "body.json", []byte(`{"name":"test"}`), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1:54321").
+			Post("/functions/v1/hello").
+			MatchHeader("Authorization", "^Bearer ey").
+			MatchHeader("Content-Type", "application/json").
+			MatchHeader("X-Custom", "value").
+			BodyString(`{"name":"test"}`).
+			Reply(http.StatusOK).
+			BodyString("Hello test")
+		// Run test
+		var buf bytes.Buffer
+		err := Run(context.Background(), "hello", "", Options{
+			Method:  http.MethodPost,
+			Data:    "@body.json",
+			Headers: []string{"x-custom: value"},
+			Role:    "authenticated",
+			Subject: "user-id",
+		}, &buf, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello test", buf.String())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid header", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), "hello", "", Options{
+			Method:  http.MethodGet,
+			Headers: []string{"invalid"},
+			Role:    "anon",
+		}, &bytes.Buffer{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "invalid header format, expected key:value: invalid")
+	})
+
+	t.Run("throws error on missing body file", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "hello", "", Options{Data: "@missing.json"}, &bytes.Buffer{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read request body:")
+	})
+}
+
+func TestInvokeRemote(t *testing.T) {
+	// Setup valid project ref
+	projectRef := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("invokes deployed function with service role key", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + projectRef + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{
+				{Name: "anon", ApiKey: nullable.NewNullableWithValue("anon-key")},
+				{Name: "service_role", ApiKey: nullable.NewNullableWithValue("service-key")},
+			})
+		gock.New("https://"+utils.GetSupabaseHost(projectRef)).
+			Get("/functions/v1/hello").
+			MatchHeader("Authorization", "Bearer service-key").
+			MatchHeader("apikey", "service-key").
+			Reply(http.StatusInternalServerError).
+			BodyString("oops")
+		// Run test
+		var buf bytes.Buffer
+		err := Run(context.Background(), "hello", projectRef, Options{
+			Method: http.MethodGet,
+			Role:   "service_role",
+		}, &buf, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "function returned error status: 500")
+		assert.Equal(t, "oops", buf.String())
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on subject", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "hello", projectRef, Options{
+			Role:    "authenticated",
+			Subject: "user-id",
+		}, &bytes.Buffer{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "--sub is only supported for local functions.")
+	})
+}
@@ -0,0 +1,264 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const (
+	SourceFunction = "function_logs"
+	SourceEdge     = "function_edge_logs"
+	// Maximum rows returned per query, further pages are fetched immediately
+	pageSize = 1000
+)
+
+// Logs take a few seconds to be ingested, so there is no point polling faster.
+var pollInterval = 5 * time.Second
+
+var levels = []string{"debug", "info", "warn", "error"}
+
+type Entry struct {
+	ID       string    `json:"id"`
+	Function string    `json:"function"`
+	Source   string    `json:"source"`
+	Time     time.Time `json:"time"`
+	Level    string    `json:"level"`
+	Message  string    `json:"message"`
+}
+
+type Options struct {
+	Follow bool
+	Level  string
+	Since  time.Duration
+}
+
+func Run(ctx context.Context, slug, projectRef string, opts Options, fsys afero.Fs) error {
+	functions, err := listFunctions(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	var filter string
+	if len(slug) > 0 {
+		id := slices.IndexFunc(functions, func(f api.FunctionResponse) bool {
+			return f.Slug == slug
+		})
+		if id < 0 {
+			return errors.Errorf("function not found: %s", slug)
+		}
+		filter = fmt.Sprintf("where m.function_id = '%s'", strings.ReplaceAll(functions[id].Id, "'", "''"))
+	}
+	names := make(map[string]string, len(functions))
+	for _, f := range functions {
+		names[f.Id] = f.Slug
+	}
+	tailers := []*tailer{
+		newTailer(SourceFunction, functionLogsQuery(filter), opts.Since),
+		newTailer(SourceEdge, edgeLogsQuery(filter), opts.Since),
+	}
+	minLevel := slices.Index(levels, opts.Level)
+	printer := newPrinter(os.Stdout, utils.OutputFormat.Value == utils.OutputJson)
+	for {
+		var entries []Entry
+		more := false
+		for _, t := range tailers {
+			rows, full, err := t.poll(ctx, projectRef)
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			more = more || full
+			for _, r := range rows {
+				e := r.toEntry(t.source, names)
+				if slices.Index(levels, e.Level) >= minLevel {
+					entries = append(entries, e)
+				}
+			}
+		}
+		slices.SortStableFunc(entries, func(a, b Entry) int {
+			return a.Time.Compare(b.Time)
+		})
+		for _, e := range entries {
+			printer.print(e)
+		}
+		if more {
+			continue
+		} else if !opts.Follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func listFunctions(ctx context.Context, projectRef string) ([]api.FunctionResponse, error) {
+	resp, err := utils.GetSupabase().V1ListAllFunctionsWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, errors.Errorf("failed to list functions: %w", err)
+	} else if resp.JSON200 == nil {
+		return nil, errors.Errorf("unexpected list functions status %d: %s", resp.StatusCode(), string(resp.Body))
+	}
+	return *resp.JSON200, nil
+}
+
+func functionLogsQuery(filter string) string {
+	return `select id, function_logs.timestamp, event_message, m.level, m.function_id
+from function_logs cross join unnest(metadata) as m
+` + filter + `
+order by timestamp asc limit ` + fmt.Sprint(pageSize)
+}
+
+func edgeLogsQuery(filter string) string {
+	return `select id, function_edge_logs.timestamp, event_message, response.status_code, m.function_id, m.execution_time_ms
+from function_edge_logs cross join unnest(metadata) as m cross join unnest(m.response) as response
+` + filter + `
+order by timestamp asc limit ` + fmt.Sprint(pageSize)
+}
+
+type logRow struct {
+	ID              string  `json:"id"`
+	Timestamp       int64   `json:"timestamp"`
+	EventMessage    string  `json:"event_message"`
+	Level           string  `json:"level"`
+	FunctionID      string  `json:"function_id"`
+	StatusCode      int     `json:"status_code"`
+	ExecutionTimeMs float64 `json:"execution_time_ms"`
+}
+
+func (r logRow) toEntry(source string, names map[string]string) Entry {
+	e := Entry{
+		ID:       r.ID,
+		Function: names[r.FunctionID],
+		Source:   source,
+		Time:     time.UnixMicro(r.Timestamp).UTC(),
+		Level:    normalizeLevel(r.Level),
+		Message:  strings.TrimSpace(r.EventMessage),
+	}
+	if len(e.Function) == 0 {
+		e.Function = r.FunctionID
+	}
+	if source == SourceEdge {
+		switch {
+		case r.StatusCode >= 500:
+			e.Level = "error"
+		case r.StatusCode >= 400:
+			e.Level = "warn"
+		default:
+			e.Level = "info"
+		}
+		e.Message = fmt.Sprintf("%s (%dms)", e.Message, int64(r.ExecutionTimeMs))
+	}
+	return e
+}
+
+func normalizeLevel(level string) string {
+	switch strings.ToLower(level) {
+	case "debug":
+		return "debug"
+	case "warn", "warning":
+		return "warn"
+	case "error":
+		return "error"
+	}
+	return "info"
+}
+
+// tailer queries a log source incrementally, starting from the timestamp of the
+// last seen row. Since the start bound is inclusive, rows at that timestamp are
+// deduplicated by ID.
+type tailer struct {
+	source string
+	sql    string
+	cursor time.Time
+	seen   map[string]struct{}
+}
+
+func newTailer(source, sql string, since time.Duration) *tailer {
+	return &tailer{
+		source: source,
+		sql:    sql,
+		cursor: time.Now().Add(-since).UTC(),
+		seen:   map[string]struct{}{},
+	}
+}
+
+// poll returns the unseen rows since the cursor, and whether the query returned
+// a full page so that the next page should be fetched immediately.
+func (t *tailer) poll(ctx context.Context, projectRef string) ([]logRow, bool, error) {
+	end := time.Now().UTC()
+	resp, err := utils.GetSupabase().V1GetProjectLogsWithResponse(ctx, projectRef, &api.V1GetProjectLogsParams{
+		Sql:               &t.sql,
+		IsoTimestampStart: &t.cursor,
+		IsoTimestampEnd:   &end,
+	})
+	if err != nil {
+		return nil, false, errors.Errorf("failed to query %s: %w", t.source, err)
+	} else if resp.JSON200 == nil || resp.JSON200.Error != nil {
+		return nil, false, errors.Errorf("unexpected query %s status %d: %s", t.source, resp.StatusCode(), string(resp.Body))
+	}
+	var body struct {
+		Result []logRow `json:"result"`
+	}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, false, errors.Errorf("failed to parse %s: %w", t.source, err)
+	}
+	var rows []logRow
+	for _, r := range body.Result {
+		if _, ok := t.seen[r.ID]; ok {
+			continue
+		}
+		if ts := time.UnixMicro(r.Timestamp).UTC(); ts.After(t.cursor) {
+			t.cursor = ts
+			clear(t.seen)
+		}
+		t.seen[r.ID] = struct{}{}
+		rows = append(rows, r)
+	}
+	// A full page without unseen rows cannot advance the cursor any further
+	full := len(body.Result) == pageSize && len(rows) > 0
+	return rows, full, nil
+}
+
+type printer struct {
+	w    io.Writer
+	json *json.Encoder
+}
+
+func newPrinter(w io.Writer, asJson bool) *printer {
+	p := printer{w: w}
+	if asJson {
+		p.json = json.NewEncoder(w)
+	}
+	return &p
+}
+
+func (p *printer) print(e Entry) {
+	if p.json != nil {
+		if err := p.json.Encode(e); err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+		return
+	}
+	level := strings.ToUpper(e.Level)
+	switch e.Level {
+	case "warn":
+		level = utils.Yellow(level)
+	case "error":
+		level = utils.Red(level)
+	}
+	fmt.Fprintf(p.w, "%s %s %s %s\n", e.Time.Local().Format(time.DateTime), utils.Aqua(e.Function), level, e.Message)
+}
@@ -0,0 +1,156 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/testing/fstest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestFunctionLogs(t *testing.T) {
+	project := apitest.RandomProjectRef()
+	ts := time.Date(2024, 1, 2, 13, 23, 37, 0, time.UTC)
+
+	t.Run("prints logs of a single function", func(t *testing.T) {
+		utils.OutputFormat.Value = utils.OutputJson
+		t.Cleanup(func() { utils.OutputFormat.Value = utils.OutputPretty })
+		t.Cleanup(fstest.MockStdout(t, `{"id":"req-1","function":"hello","source":"function_edge_logs","time":"2024-01-02T13:23:37Z","level":"error","message":"POST | 500 | /functions/v1/hello (12ms)"}
+{"id":"log-2","function":"hello","source":"function_logs","time":"2024-01-02T13:23:38Z","level":"error","message":"TypeError: oops"}
+`))
+		t.Cleanup(apitest.MockPlatformAPI(t))
+		// Setup mock api
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{
+				{Id: "fn-1", Slug: "hello"},
+				{Id: "fn-2", Slug: "world"},
+			})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+project+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "(?s)from function_logs .*where m.function_id = 'fn-1'").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"result": []map[string]any{
+				{"id": "log-1", "timestamp": ts.UnixMicro(), "event_message": "booted", "level": "log", "function_id": "fn-1"},
+				{"id": "log-2", "timestamp": ts.Add(time.Second).UnixMicro(), "event_message": "TypeError: oops\n", "level": "error", "function_id": "fn-1"},
+			}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+project+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "(?s)from function_edge_logs .*where m.function_id = 'fn-1'").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"result": []map[string]any{
+				{"id": "req-1", "timestamp": ts.UnixMicro(), "event_message": "POST | 500 | /functions/v1/hello", "status_code": 500, "function_id": "fn-1", "execution_time_ms": 12},
+			}})
+		// Run test
+		err := Run(context.Background(), "hello", project, Options{Level: "error", Since: time.Hour}, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on unknown function", func(t *testing.T) {
+		t.Cleanup(apitest.MockPlatformAPI(t))
+		// Setup mock api
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		// Run test
+		err := Run(context.Background(), "hello", project, Options{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "function not found: hello")
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		t.Cleanup(apitest.MockPlatformAPI(t))
+		// Setup mock api
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/analytics/endpoints/logs.all").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"error": "invalid query"})
+		// Run test
+		err := Run(context.Background(), "", project, Options{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, `unexpected query function_logs status 200: {"error":"invalid query"}`)
+	})
+}
+
+func TestTailerDeduplicates(t *testing.T) {
+	project := apitest.RandomProjectRef()
+	ts := time.Now().Add(-time.Minute).Truncate(time.Second).UTC()
+	t.Cleanup(apitest.MockPlatformAPI(t))
+	// Setup mock api
+	rows := []map[string]any{
+		{"id": "log-1", "timestamp": ts.UnixMicro()},
+		{"id": "log-2", "timestamp": ts.UnixMicro()},
+	}
+	gock.New(utils.DefaultApiHost).
+		Get("/v1/projects/" + project + "/analytics/endpoints/logs.all").
+		Reply(http.StatusOK).
+		JSON(map[string]any{"result": rows[:1]})
+	gock.New(utils.DefaultApiHost).
+		Get("/v1/projects/"+project+"/analytics/endpoints/logs.all").
+		MatchParam("iso_timestamp_start", ts.Format(time.RFC3339)).
+		Reply(http.StatusOK).
+		JSON(map[string]any{"result": rows})
+	tailer := newTailer(SourceFunction, "select 1", time.Hour)
+	// Run test
+	first, _, err := tailer.poll(context.Background(), project)
+	require.NoError(t, err)
+	second, more, err := tailer.poll(context.Background(), project)
+	require.NoError(t, err)
+	// Check output
+	assert.Len(t, first, 1)
+	assert.False(t, more)
+	require.Len(t, second, 1)
+	assert.Equal(t, "log-2", second[0].ID)
+	assert.Empty(t, apitest.ListUnmatchedRequests())
+}
+
+func TestTailerPagination(t *testing.T) {
+	project := apitest.RandomProjectRef()
+	ts := time.Now().Add(-time.Minute).Truncate(time.Second).UTC()
+	t.Cleanup(apitest.MockPlatformAPI(t))
+	// Setup mock api
+	rows := make([]map[string]any, pageSize)
+	for i := range rows {
+		rows[i] = map[string]any{"id": fmt.Sprintf("log-%d", i), "timestamp": ts.UnixMicro()}
+	}
+	gock.New(utils.DefaultApiHost).
+		Get("/v1/projects/" + project + "/analytics/endpoints/logs.all").
+		Reply(http.StatusOK).
+		JSON(map[string]any{"result": rows[:1]})
+	gock.New(utils.DefaultApiHost).
+		Get("/v1/projects/" + project + "/analytics/endpoints/logs.all").
+		Times(2).
+		Reply(http.StatusOK).
+		JSON(map[string]any{"result": rows})
+	tailer := newTailer(SourceFunction, "select 1", time.Hour)
+	_, _, err := tailer.poll(context.Background(), project)
+	require.NoError(t, err)
+	// Run test
+	second, more, err := tailer.poll(context.Background(), project)
+	require.NoError(t, err)
+	third, stuck, err := tailer.poll(context.Background(), project)
+	require.NoError(t, err)
+	// Check output
+	assert.Len(t, second, pageSize-1)
+	assert.True(t, more)
+	assert.Empty(t, third)
+	assert.False(t, stuck)
+	assert.Empty(t, apitest.ListUnmatchedRequests())
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/containerd/errdefs"
 	"github.com/docker/cli/cli/compose/loader"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/mount"
@@ -54,7 +55,7 @@ type RuntimeOption struct {
 func (i *RuntimeOption) toArgs() []string {
 	flags := []string{}
 	if i.InspectMode != nil {
-		flags = append(flags, fmt.Sprintf("--%s=0.0.0.0:%d", i.InspectMode.toFlag(), dockerRuntimeInspectorPort))
+		flags = append(flags, fmt.Sprintf("--%s=0.0.0.0:%d", i.InspectMode.toFlag(), DockerRuntimeInspectorPort))
 		if i.InspectMain {
 			flags = append(flags, "--inspect-main")
 		}
@@ -64,7 +65,9 @@ func (i *RuntimeOption) toArgs() []string {
 
 const (
 	dockerRuntimeServerPort    = 8081
-	dockerRuntimeInspectorPort = 8083
+	DockerRuntimeInspectorPort = 8083
+	// KongInspectorPort is where Kong terminates TLS for the inspector
+	KongInspectorPort = 9229
 )
 
 //go:embed templates/main.ts
@@ -203,11 +206,17 @@ EOF
 	exposedPorts := nat.PortSet{dockerRuntimePort: struct{}{}}
 	portBindings := nat.PortMap{}
 	if runtimeOption.InspectMode != nil {
-		dockerInspectorPort := nat.Port(fmt.Sprintf("%d/tcp", dockerRuntimeInspectorPort))
+		dockerInspectorPort := nat.Port(fmt.Sprintf("%d/tcp", DockerRuntimeInspectorPort))
 		exposedPorts[dockerInspectorPort] = struct{}{}
-		portBindings[dockerInspectorPort] = []nat.PortBinding{{
-			HostPort: strconv.FormatUint(uint64(utils.Config.EdgeRuntime.InspectorPort), 10),
-		}}
+		proxied, err := isInspectorProxied(ctx)
+		if err != nil {
+			return err
+		}
+		if !proxied {
+			portBindings[dockerInspectorPort] = []nat.PortBinding{{
+				HostPort: strconv.FormatUint(uint64(utils.Config.EdgeRuntime.InspectorPort), 10),
+			}}
+		}
 	}
 	// 5. Start container
 	_, err = utils.DockerStart(
@@ -224,6 +233,8 @@ EOF
 			Binds:        binds,
 			PortBindings: portBindings,
 			Resources: container.Resources{
+				Memory:   utils.Config.EdgeRuntime.Resources.MemoryBytes(),
+				NanoCPUs: utils.Config.EdgeRuntime.Resources.NanoCpus(),
 				// Raise nofile to accommodate FD usage from many concurrent Deno isolates (see #5151).
 				Ulimits: []*container.Ulimit{
 					{
@@ -246,6 +257,24 @@ EOF
 	return err
 }
 
+// isInspectorProxied returns true if Kong was started with a TLS proxy that
+// publishes the inspector port, in which case it must not be bound again.
+func isInspectorProxied(ctx context.Context) (bool, error) {
+	if !utils.LocalCertsEnabled {
+		return false, nil
+	}
+	resp, err := utils.Docker.ContainerInspect(ctx, utils.KongId)
+	if errdefs.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Errorf("failed to inspect kong container: %w", err)
+	} else if resp.HostConfig == nil {
+		return false, nil
+	}
+	_, ok := resp.HostConfig.PortBindings[nat.Port(fmt.Sprintf("%d/tcp", KongInspectorPort))]
+	return ok, nil
+}
+
 func parseEnvFile(envFilePath string, fsys afero.Fs) ([]string, error) {
 	if envFilePath == "" {
 		if f, err := fsys.Stat(utils.FallbackEnvFilePath); err == nil && !f.IsDir() {
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
 	"github.com/h2non/gock"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -183,3 +184,61 @@ func TestServeFunctions(t *testing.T) {
 		assert.Equal(t, `{"hello":{"verifyJWT":true,"entrypointPath":"testdata/functions/hello/index.ts","staticFiles":["testdata/image.png"]}}`, configString)
 	})
 }
+
+func TestInspectorProxied(t *testing.T) {
+	utils.LocalCertsEnabled = true
+	defer func() { utils.LocalCertsEnabled = false }()
+
+	t.Run("detects inspector published by kong", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.KongId + "/json").
+			Reply(http.StatusOK).
+			JSON(container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				HostConfig: &container.HostConfig{PortBindings: nat.PortMap{
+					"9229/tcp": []nat.PortBinding{{HostPort: "8083"}},
+				}},
+			}})
+		// Run test
+		proxied, err := isInspectorProxied(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, proxied)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("publishes inspector if kong has no proxy", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.KongId + "/json").
+			Reply(http.StatusOK).
+			JSON(container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				HostConfig: &container.HostConfig{},
+			}})
+		// Run test
+		proxied, err := isInspectorProxied(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, proxied)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("publishes inspector if kong is excluded", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.KongId + "/json").
+			Reply(http.StatusNotFound)
+		// Run test
+		proxied, err := isInspectorProxied(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, proxied)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
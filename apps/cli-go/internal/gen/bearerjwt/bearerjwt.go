@@ -82,3 +82,16 @@ func getSigningKey(ctx context.Context) (*config.JWK, error) {
 	fmt.Fprintln(os.Stderr, "Selected key ID:", choice.Summary)
 	return &utils.Config.Auth.SigningKeys[choice.Index], nil
 }
+
+// NewLocalToken signs claims with the same key as the local API keys, so that
+// the token is accepted by local services without prompting for a signing key.
+func NewLocalToken(claims config.CustomClaims) (string, error) {
+	if len(utils.Config.Auth.SigningKeysPath) > 0 && len(utils.Config.Auth.SigningKeys) > 0 {
+		return config.GenerateAsymmetricJWT(utils.Config.Auth.SigningKeys[0], claims)
+	}
+	signed, err := claims.NewToken().SignedString([]byte(utils.Config.Auth.JwtSecret.Value))
+	if err != nil {
+		return "", errors.Errorf("failed to sign JWT: %w", err)
+	}
+	return signed, nil
+}
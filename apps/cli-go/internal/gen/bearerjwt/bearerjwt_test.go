@@ -192,3 +192,18 @@ func TestGenerateToken(t *testing.T) {
 		assert.ErrorContains(t, err, "signing key not found: test-key")
 	})
 }
+
+func TestNewLocalToken(t *testing.T) {
+	utils.Config.Auth.JwtSecret.Value = "super-secret-jwt-token-with-at-least-32-characters-long"
+	claims := config.CustomClaims{Role: "service_role"}
+	// Run test
+	signed, err := NewLocalToken(claims)
+	// Check error
+	assert.NoError(t, err)
+	token, err := jwt.NewParser().Parse(signed, func(t *jwt.Token) (any, error) {
+		return []byte(utils.Config.Auth.JwtSecret.Value), nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, token.Valid)
+	assert.Equal(t, "service_role", token.Claims.(jwt.MapClaims)["role"])
+}
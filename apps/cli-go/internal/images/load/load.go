@@ -0,0 +1,55 @@
+package load
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/docker/cli/cli/streams"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, path string, fsys afero.Fs) error {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return errors.Errorf("failed to open image bundle: %w", err)
+	}
+	defer f.Close()
+	fmt.Fprintln(os.Stderr, "Loading images from "+utils.Bold(path)+"...")
+	if err := loadBundle(ctx, f); err != nil {
+		return err
+	}
+	// Verify that the bundle satisfies the lockfile
+	if err := utils.LoadImageLock(fsys); err != nil {
+		return err
+	}
+	if err := utils.AssertLockedImages(ctx); err != nil {
+		return err
+	}
+	if len(utils.LockedImages) > 0 {
+		fmt.Fprintf(os.Stderr, "Verified %d images against %s\n", len(utils.LockedImages), utils.Bold(utils.ImageLockPath))
+	}
+	return nil
+}
+
+func loadBundle(ctx context.Context, r io.Reader) error {
+	resp, err := utils.Docker.ImageLoad(ctx, r)
+	if err != nil {
+		return errors.Errorf("failed to load docker images: %w", err)
+	}
+	defer resp.Body.Close()
+	if !resp.JSON {
+		if _, err := io.Copy(os.Stderr, resp.Body); err != nil {
+			return errors.Errorf("failed to read load response: %w", err)
+		}
+		return nil
+	}
+	if err := jsonmessage.DisplayJSONMessagesToStream(resp.Body, streams.NewOut(os.Stderr), nil); err != nil {
+		return errors.Errorf("failed to display json stream: %w", err)
+	}
+	return nil
+}
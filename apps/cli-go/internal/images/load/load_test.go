@@ -0,0 +1,72 @@
+package load
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestLoadImages(t *testing.T) {
+	t.Run("loads bundle and verifies lockfile", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "bundle.tar", []byte("archive"), 0644))
+		require.NoError(t, utils.WriteImageLock(utils.ImageLock{"redis:7-alpine": "sha256:redis"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v"+utils.Docker.ClientVersion()+"/images/load").
+			Reply(http.StatusOK).
+			SetHeader("Content-Type", "application/json").
+			BodyString(`{"stream":"Loaded image: redis:7-alpine\n"}`)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/redis:7-alpine/json").
+			Reply(http.StatusOK).
+			// Repo digests are not kept by docker save and load
+			JSON(image.InspectResponse{ID: "sha256:redis", RepoDigests: []string{}})
+		// Run test
+		err := Run(context.Background(), "bundle.tar", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on mismatched image", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "bundle.tar", []byte("archive"), 0644))
+		require.NoError(t, utils.WriteImageLock(utils.ImageLock{"redis:7-alpine": "sha256:redis"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/images/load").
+			Reply(http.StatusOK).
+			BodyString("Loaded image: redis:7-alpine\n")
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/redis:7-alpine/json").
+			Reply(http.StatusOK).
+			JSON(image.InspectResponse{ID: "sha256:other"})
+		// Run test
+		err := Run(context.Background(), "bundle.tar", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "expected sha256:redis but found sha256:other")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing bundle", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "bundle.tar", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to open image bundle:")
+	})
+}
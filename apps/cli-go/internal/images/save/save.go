@@ -0,0 +1,88 @@
+package save
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/containerd/errdefs"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+func Run(ctx context.Context, path string, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	return SaveImages(ctx, path, GetImages(), fsys)
+}
+
+// GetImages returns the images required to start the local stack with the
+// current config and service versions.
+func GetImages() []string {
+	var images []string
+	for _, service := range utils.GetServices() {
+		images = append(images, service.Image)
+	}
+	sort.Strings(images)
+	return utils.RemoveDuplicates(images)
+}
+
+// SaveImages writes images to a single archive and records their ids in the
+// image lockfile.
+func SaveImages(ctx context.Context, path string, images []string, fsys afero.Fs) error {
+	lock := make(utils.ImageLock, len(images))
+	for _, imageUrl := range images {
+		id, err := resolveImage(ctx, imageUrl)
+		if err != nil {
+			return err
+		}
+		lock[imageUrl] = id
+	}
+	fmt.Fprintf(os.Stderr, "Saving %d images to %s...\n", len(images), utils.Bold(path))
+	if err := writeBundle(ctx, path, images, fsys); err != nil {
+		return err
+	}
+	if err := utils.WriteImageLock(lock, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Saved image bundle to "+utils.Bold(path))
+	fmt.Fprintln(os.Stderr, "Locked image ids in "+utils.Bold(utils.ImageLockPath))
+	return nil
+}
+
+// resolveImage pulls missing images and returns the local image id.
+func resolveImage(ctx context.Context, imageUrl string) (string, error) {
+	resp, err := utils.Docker.ImageInspect(ctx, imageUrl)
+	if errdefs.IsNotFound(err) {
+		if err := utils.DockerImagePullWithRetry(ctx, imageUrl, 2); err != nil {
+			return "", err
+		}
+		resp, err = utils.Docker.ImageInspect(ctx, imageUrl)
+	}
+	if err != nil {
+		return "", errors.Errorf("failed to inspect docker image: %w", err)
+	}
+	return resp.ID, nil
+}
+
+func writeBundle(ctx context.Context, path string, images []string, fsys afero.Fs) error {
+	out, err := utils.Docker.ImageSave(ctx, images)
+	if err != nil {
+		return errors.Errorf("failed to save docker images: %w", err)
+	}
+	defer out.Close()
+	f, err := fsys.Create(path)
+	if err != nil {
+		return errors.Errorf("failed to create image bundle: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, out); err != nil {
+		return errors.Errorf("failed to write image bundle: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package save
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestSaveImages(t *testing.T) {
+	images := []string{"supabase/postgres:17", "redis:7-alpine"}
+
+	t.Run("saves bundle and lockfile", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/supabase/postgres:17/json").
+			Reply(http.StatusOK).
+			JSON(image.InspectResponse{ID: "sha256:postgres"})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/redis:7-alpine/json").
+			Reply(http.StatusOK).
+			JSON(image.InspectResponse{ID: "sha256:redis"})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v"+utils.Docker.ClientVersion()+"/images/get").
+			MatchParam("names", "supabase/postgres:17").
+			Reply(http.StatusOK).
+			BodyString("archive")
+		// Run test
+		err := SaveImages(context.Background(), "bundle.tar", images, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		bundle, err := afero.ReadFile(fsys, "bundle.tar")
+		require.NoError(t, err)
+		assert.Equal(t, "archive", string(bundle))
+		lock, err := afero.ReadFile(fsys, utils.ImageLockPath)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"supabase/postgres:17": "sha256:postgres",
+			"redis:7-alpine": "sha256:redis"
+		}`, string(lock))
+	})
+
+	t.Run("throws error on inspect failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/supabase/postgres:17/json").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := SaveImages(context.Background(), "bundle.tar", images, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		exists, err := afero.Exists(fsys, utils.ImageLockPath)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
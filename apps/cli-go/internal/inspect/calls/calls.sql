@@ -20,7 +20,8 @@ SELECT
         0
       )
     )
-  )::text AS sync_io_time
+  )::text AS sync_io_time,
+  queryid::text AS queryid
 FROM extensions.pg_stat_statements s
 ORDER BY calls DESC
 LIMIT 10
@@ -32,6 +32,7 @@ func TestCallsCommand(t *testing.T) {
 				Ncalls:          "0.9",
 				Sync_io_time:    "0.9",
 				Query:           "SELECT 1",
+				Queryid:         "1",
 			})
 		// Run test
 		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
@@ -0,0 +1,481 @@
+package inspect
+
+import (
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+const (
+	OutputTable    = "table"
+	OutputMarkdown = "markdown"
+)
+
+var DiffOutputs = []string{OutputTable, OutputMarkdown, utils.OutputJson}
+
+type metricKind int
+
+const (
+	kindNumber metricKind = iota
+	kindSize
+	kindInterval
+	kindBool
+)
+
+type metric struct {
+	column string
+	kind   metricKind
+	// derive computes the metric from other columns when set
+	derive func(row map[string]string) (float64, error)
+}
+
+func (m metric) parse(row map[string]string) (float64, error) {
+	if m.derive != nil {
+		return m.derive(row)
+	}
+	return parseMetric(row[m.column], m.kind)
+}
+
+func meanExecTime(row map[string]string) (float64, error) {
+	total, err := parseInterval(row["total_exec_time"])
+	if err != nil {
+		return 0, err
+	}
+	calls, err := parseMetric(row["ncalls"], kindNumber)
+	if err != nil || calls == 0 {
+		return 0, err
+	}
+	return total / calls, nil
+}
+
+// diffSpec describes how rows of a report CSV are joined and compared.
+type diffSpec struct {
+	report  string
+	keys    []string
+	metrics []metric
+}
+
+var diffSpecs = []diffSpec{{
+	report: "table_stats",
+	keys:   []string{"name"},
+	metrics: []metric{
+		{column: "table_size", kind: kindSize},
+		{column: "index_size", kind: kindSize},
+		{column: "total_size", kind: kindSize},
+		{column: "estimated_row_count", kind: kindNumber},
+		{column: "seq_scans", kind: kindNumber},
+	},
+}, {
+	report: "index_stats",
+	keys:   []string{"name"},
+	metrics: []metric{
+		{column: "size", kind: kindSize},
+		{column: "index_scans", kind: kindNumber},
+		{column: "unused", kind: kindBool},
+	},
+}, {
+	report: "bloat",
+	keys:   []string{"type", "name"},
+	metrics: []metric{
+		{column: "bloat", kind: kindNumber},
+		{column: "waste", kind: kindSize},
+	},
+}, {
+	report: "calls",
+	keys:   []string{"queryid"},
+	metrics: []metric{
+		{column: "total_exec_time", kind: kindInterval},
+		{column: "ncalls", kind: kindNumber},
+		{column: "mean_exec_time", kind: kindInterval, derive: meanExecTime},
+	},
+}, {
+	report: "outliers",
+	keys:   []string{"queryid"},
+	metrics: []metric{
+		{column: "total_exec_time", kind: kindInterval},
+		{column: "ncalls", kind: kindNumber},
+		{column: "mean_exec_time", kind: kindInterval, derive: meanExecTime},
+	},
+}}
+
+const (
+	StatusAdded   = "added"
+	StatusRemoved = "removed"
+	StatusChanged = "changed"
+)
+
+// Delta is the change of a single metric between two reports. Sizes are
+// measured in bytes and durations in milliseconds.
+type Delta struct {
+	Report  string   `json:"report"`
+	Key     string   `json:"key"`
+	Metric  string   `json:"metric"`
+	Status  string   `json:"status"`
+	Old     *float64 `json:"old"`
+	New     *float64 `json:"new"`
+	Delta   float64  `json:"delta"`
+	Percent *float64 `json:"percent_change"`
+	kind    metricKind
+}
+
+type RuleResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Matches string `json:"matches"`
+}
+
+type DiffResult struct {
+	Deltas []Delta      `json:"deltas"`
+	Rules  []RuleResult `json:"rules"`
+}
+
+func Diff(ctx context.Context, oldDir, newDir, output string, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	deltas, err := DiffReports(oldDir, newDir, fsys)
+	if err != nil {
+		return err
+	}
+	rules, err := checkDiffRules(ctx, deltas)
+	if err != nil {
+		return err
+	}
+	result := DiffResult{Deltas: deltas, Rules: rules}
+	switch output {
+	case utils.OutputJson:
+		if result.Deltas == nil {
+			result.Deltas = []Delta{}
+		}
+		return utils.EncodeOutput(output, os.Stdout, result)
+	case OutputMarkdown:
+		fmt.Print(toMarkdown(result))
+		return nil
+	}
+	return utils.RenderTable(toMarkdown(result))
+}
+
+// DiffReports joins the CSV files of two report directories by their natural
+// keys and returns the metrics that changed.
+func DiffReports(oldDir, newDir string, fsys afero.Fs) ([]Delta, error) {
+	var result []Delta
+	for _, spec := range diffSpecs {
+		name := spec.report + ".csv"
+		oldRows, err := readReport(filepath.Join(oldDir, name), fsys)
+		if err != nil {
+			return nil, err
+		}
+		newRows, err := readReport(filepath.Join(newDir, name), fsys)
+		if err != nil {
+			return nil, err
+		}
+		if oldRows == nil || newRows == nil {
+			fmt.Fprintln(os.Stderr, "Skipping missing report:", name)
+			continue
+		}
+		deltas, err := spec.compare(oldRows, newRows)
+		if err != nil {
+			return nil, errors.Errorf("failed to compare %s: %w", name, err)
+		}
+		result = append(result, deltas...)
+	}
+	return result, nil
+}
+
+// readReport returns nil rows if the report file does not exist.
+func readReport(path string, fsys afero.Fs) ([]map[string]string, error) {
+	f, err := fsys.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to open report: %w", err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, errors.Errorf("failed to read %s: %w", path, err)
+	}
+	rows := []map[string]string{}
+	if len(records) == 0 {
+		return rows, nil
+	}
+	header := records[0]
+	for _, r := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, h := range header {
+			if i < len(r) {
+				row[h] = r[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (s diffSpec) key(row map[string]string) string {
+	parts := make([]string, len(s.keys))
+	for i, k := range s.keys {
+		parts[i] = row[k]
+	}
+	return strings.Join(parts, ":")
+}
+
+func (s diffSpec) index(rows []map[string]string) (map[string]map[string]string, []string) {
+	index := make(map[string]map[string]string, len(rows))
+	var keys []string
+	for _, r := range rows {
+		k := s.key(r)
+		if _, ok := index[k]; !ok {
+			keys = append(keys, k)
+		}
+		index[k] = r
+	}
+	return index, keys
+}
+
+func (s diffSpec) compare(oldRows, newRows []map[string]string) ([]Delta, error) {
+	oldIndex, oldKeys := s.index(oldRows)
+	newIndex, newKeys := s.index(newRows)
+	keys := newKeys
+	for _, k := range oldKeys {
+		if _, ok := newIndex[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var result []Delta
+	for _, k := range keys {
+		oldRow, hasOld := oldIndex[k]
+		newRow, hasNew := newIndex[k]
+		for _, m := range s.metrics {
+			d := Delta{Report: s.report, Key: k, Metric: m.column, Status: StatusChanged, kind: m.kind}
+			if hasOld {
+				v, err := m.parse(oldRow)
+				if err != nil {
+					return nil, err
+				}
+				d.Old = &v
+			} else {
+				d.Status = StatusAdded
+			}
+			if hasNew {
+				v, err := m.parse(newRow)
+				if err != nil {
+					return nil, err
+				}
+				d.New = &v
+			} else {
+				d.Status = StatusRemoved
+			}
+			if d.Delta = value(d.New) - value(d.Old); d.Delta == 0 {
+				continue
+			}
+			if d.Old != nil && d.New != nil && *d.Old != 0 {
+				p := math.Round(d.Delta / *d.Old * 10000) / 100
+				d.Percent = &p
+			}
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func value(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func parseMetric(value string, kind metricKind) (float64, error) {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case kindSize:
+		return parseSize(value)
+	case kindInterval:
+		return parseInterval(value)
+	case kindBool:
+		if value == "t" || value == "true" {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if len(value) == 0 {
+		return 0, nil
+	}
+	value = strings.TrimSuffix(strings.ReplaceAll(value, ",", ""), "%")
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.Errorf("failed to parse number: %w", err)
+	}
+	return f, nil
+}
+
+var sizeUnits = map[string]float64{
+	"bytes": 1,
+	"kb":    1 << 10,
+	"mb":    1 << 20,
+	"gb":    1 << 30,
+	"tb":    1 << 40,
+	"pb":    1 << 50,
+}
+
+// parseSize converts the output of pg_size_pretty to bytes.
+func parseSize(value string) (float64, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	num, unit, _ := strings.Cut(value, " ")
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, errors.Errorf("failed to parse size: %w", err)
+	}
+	scale, ok := sizeUnits[strings.ToLower(unit)]
+	if !ok {
+		return 0, errors.Errorf("unknown size unit: %s", value)
+	}
+	return f * scale, nil
+}
+
+var intervalPattern = regexp.MustCompile(`^(?:(-?\d+) days? ?)?(?:(-?)(\d+):(\d+):(\d+(?:\.\d+)?))?$`)
+
+// parseInterval converts a postgres interval to milliseconds.
+func parseInterval(value string) (float64, error) {
+	matches := intervalPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, errors.Errorf("failed to parse interval: %s", value)
+	}
+	var ms float64
+	if len(matches[1]) > 0 {
+		days, _ := strconv.ParseFloat(matches[1], 64)
+		ms += days * 24 * 60 * 60 * 1000
+	}
+	if len(matches[3]) > 0 {
+		h, _ := strconv.ParseFloat(matches[3], 64)
+		m, _ := strconv.ParseFloat(matches[4], 64)
+		s, _ := strconv.ParseFloat(matches[5], 64)
+		t := ((h*60+m)*60 + s) * 1000
+		if matches[2] == "-" {
+			t = -t
+		}
+		ms += t
+	}
+	return ms, nil
+}
+
+func formatMetric(v float64, kind metricKind) string {
+	switch kind {
+	case kindSize:
+		return formatSize(v)
+	case kindInterval:
+		return strconv.FormatFloat(v, 'f', 2, 64) + "ms"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatSize(v float64) string {
+	units := []string{"bytes", "kB", "MB", "GB", "TB"}
+	abs := math.Abs(v)
+	i := 0
+	for ; i < len(units)-1 && abs >= 10*1024; i++ {
+		abs /= 1024
+		v /= 1024
+	}
+	return strconv.FormatFloat(math.Round(v), 'f', -1, 64) + " " + units[i]
+}
+
+func toMarkdown(result DiffResult) string {
+	var b strings.Builder
+	b.WriteString("|REPORT|KEY|METRIC|STATUS|OLD|NEW|DELTA|\n|-|-|-|-|-|-|-|\n")
+	for _, d := range result.Deltas {
+		old, new := "-", "-"
+		if d.Old != nil {
+			old = formatMetric(*d.Old, d.kind)
+		}
+		if d.New != nil {
+			new = formatMetric(*d.New, d.kind)
+		}
+		delta := formatMetric(d.Delta, d.kind)
+		if d.Delta > 0 {
+			delta = "+" + delta
+		}
+		if d.Percent != nil {
+			delta += fmt.Sprintf(" (%+.2f%%)", *d.Percent)
+		}
+		key := strings.ReplaceAll(d.Key, "|", `\|`)
+		fmt.Fprintf(&b, "|`%s`|`%s`|`%s`|`%s`|`%s`|`%s`|`%s`|\n", d.Report, key, d.Metric, d.Status, old, new, delta)
+	}
+	if len(result.Rules) > 0 {
+		b.WriteString("\n" + rulesToMarkdown(result.Rules))
+	}
+	return b.String()
+}
+
+//go:embed templates/diff_rules.toml
+var diffRulesConfig string
+
+// checkDiffRules writes deltas to diff.csv so that rules can assert on them
+// using the same csvq engine as report rules.
+func checkDiffRules(ctx context.Context, deltas []Delta) ([]RuleResult, error) {
+	if len(utils.Config.Experimental.Inspect.DiffRules) == 0 {
+		fmt.Fprintln(os.Stderr, "Loading default diff rules...")
+		if _, err := toml.Decode(diffRulesConfig, &utils.Config.Experimental.Inspect); err != nil {
+			return nil, errors.Errorf("failed load default diff rules: %w", err)
+		}
+	}
+	tmpDir, err := os.MkdirTemp("", "supabase-inspect-diff-")
+	if err != nil {
+		return nil, errors.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := writeDeltas(filepath.Join(tmpDir, "diff.csv"), deltas); err != nil {
+		return nil, err
+	}
+	var rules []reportRule
+	for _, r := range utils.Config.Experimental.Inspect.DiffRules {
+		rules = append(rules, reportRule{Query: r.Query, Name: r.Name, Pass: r.Pass, Fail: r.Fail})
+	}
+	return evaluateRules(ctx, tmpDir, rules)
+}
+
+func writeDeltas(path string, deltas []Delta) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Errorf("failed to create diff file: %w", err)
+	}
+	defer f.Close()
+	return encodeDeltas(f, deltas)
+}
+
+func encodeDeltas(w io.Writer, deltas []Delta) error {
+	format := func(v *float64) string {
+		if v == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64)
+	}
+	cw := csv.NewWriter(w)
+	records := [][]string{{"report", "key", "metric", "status", "old", "new", "delta", "percent_change"}}
+	for _, d := range deltas {
+		records = append(records, []string{d.Report, d.Key, d.Metric, d.Status, format(d.Old), format(d.New), format(&d.Delta), format(d.Percent)})
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return errors.Errorf("failed to write diff file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package inspect
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func writeReport(t *testing.T, fsys afero.Fs, dir, name, contents string) {
+	require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, name), []byte(contents), 0644))
+}
+
+func TestDiffReports(t *testing.T) {
+	t.Run("joins reports by natural keys", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		writeReport(t, fsys, "old", "table_stats.csv", `name,table_size,index_size,total_size,estimated_row_count,seq_scans
+public.todos,16 MB,16 kB,17 MB,1000,10
+public.dropped,8192 bytes,0 bytes,8192 bytes,0,0
+`)
+		writeReport(t, fsys, "new", "table_stats.csv", `name,table_size,index_size,total_size,estimated_row_count,seq_scans
+public.todos,32 MB,16 kB,33 MB,1000,10
+`)
+		writeReport(t, fsys, "old", "calls.csv", `total_exec_time,prop_exec_time,ncalls,sync_io_time,query,queryid
+00:00:01,100.0%,"1,000",00:00:00,select 1,42
+`)
+		writeReport(t, fsys, "new", "calls.csv", `total_exec_time,prop_exec_time,ncalls,sync_io_time,query,queryid
+00:00:04,100.0%,"2,000",00:00:00,select 1,42
+`)
+		// Run test
+		deltas, err := DiffReports("old", "new", fsys)
+		// Check error
+		assert.NoError(t, err)
+		var summary []string
+		for _, d := range deltas {
+			summary = append(summary, d.Report+" "+d.Key+" "+d.Metric+" "+d.Status)
+		}
+		assert.Equal(t, []string{
+			"table_stats public.dropped table_size removed",
+			"table_stats public.dropped total_size removed",
+			"table_stats public.todos table_size changed",
+			"table_stats public.todos total_size changed",
+			"calls 42 total_exec_time changed",
+			"calls 42 ncalls changed",
+			"calls 42 mean_exec_time changed",
+		}, summary)
+		mean := deltas[len(deltas)-1]
+		assert.Equal(t, 1.0, *mean.Old)
+		assert.Equal(t, 2.0, *mean.New)
+		assert.Equal(t, 100.0, *mean.Percent)
+	})
+
+	t.Run("throws error on malformed value", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		writeReport(t, fsys, "old", "bloat.csv", "type,name,bloat,waste\ntable,public.todos,1.0,8 kB\n")
+		writeReport(t, fsys, "new", "bloat.csv", "type,name,bloat,waste\ntable,public.todos,1.0,8 zB\n")
+		// Run test
+		_, err := DiffReports("old", "new", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to compare bloat.csv: unknown size unit: 8 zB")
+	})
+}
+
+func TestDiffCommand(t *testing.T) {
+	t.Run("evaluates rules against deltas", func(t *testing.T) {
+		utils.Config.Experimental.Inspect.DiffRules = nil
+		fsys := afero.NewMemMapFs()
+		writeReport(t, fsys, "old", "index_stats.csv", "name,size,percent_used,index_scans,seq_scans,unused\npublic.todos_pkey,16 kB,100%,5,0,f\n")
+		writeReport(t, fsys, "new", "index_stats.csv", "name,size,percent_used,index_scans,seq_scans,unused\npublic.todos_pkey,16 kB,0%,5,0,t\n")
+		deltas, err := DiffReports("old", "new", fsys)
+		require.NoError(t, err)
+		// Run test
+		rules, err := checkDiffRules(context.Background(), deltas)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, rules, RuleResult{
+			Name:    "No new unused indexes",
+			Status:  "At least one index became unused",
+			Matches: "public.todos_pkey",
+		})
+		for _, r := range rules {
+			assert.NotContains(t, r.Status, "syntax error")
+		}
+	})
+
+	t.Run("outputs json", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Diff(context.Background(), "old", "new", utils.OutputJson, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
+
+func TestParseMetric(t *testing.T) {
+	t.Run("parses pretty size", func(t *testing.T) {
+		for input, expected := range map[string]float64{
+			"8192 bytes": 8192,
+			"16 kB":      16 * 1024,
+			"1.5 GB":     1.5 * 1024 * 1024 * 1024,
+		} {
+			size, err := parseSize(input)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, size)
+		}
+	})
+
+	t.Run("parses interval", func(t *testing.T) {
+		for input, expected := range map[string]float64{
+			"00:00:01.5":      1500,
+			"1 day 00:01:00":  24*60*60*1000 + 60*1000,
+			"2 days":          2 * 24 * 60 * 60 * 1000,
+			"-00:00:00.25":    -250,
+			"01:00:00.000001": 3600000.001,
+		} {
+			ms, err := parseInterval(input)
+			assert.NoError(t, err)
+			assert.InDelta(t, expected, ms, 1e-6)
+		}
+	})
+}
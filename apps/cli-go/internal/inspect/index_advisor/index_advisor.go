@@ -0,0 +1,369 @@
+package index_advisor
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/inspect/output"
+	"github.com/supabase/cli/internal/migration/new"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/parser"
+	"github.com/supabase/cli/pkg/pgxv5"
+)
+
+// Queries are nested one level deeper than other inspect commands so that they
+// are not picked up by inspect report.
+var (
+	//go:embed queries/top_queries.sql
+	TopQueriesQuery string
+	//go:embed queries/columns.sql
+	ColumnsQuery string
+
+	ServerVersion = "SHOW server_version_num"
+	// Servers before Postgres 16 cannot explain a generic plan directly
+	ForceGenericPlan = "SET LOCAL plan_cache_mode = force_generic_plan"
+	CreateHypopg     = "CREATE EXTENSION IF NOT EXISTS hypopg WITH SCHEMA extensions"
+	CreateIndex      = "SELECT indexname FROM extensions.hypopg_create_index($1)"
+	ResetHypopg      = "SELECT extensions.hypopg_reset()"
+)
+
+type Index struct {
+	Schema string
+	Table  string
+	Column string
+}
+
+// SQL omits the index name so that Postgres picks one that does not collide
+// with existing indexes.
+func (i Index) SQL(concurrently bool) string {
+	var sql strings.Builder
+	sql.WriteString("CREATE INDEX ")
+	if concurrently {
+		sql.WriteString("CONCURRENTLY ")
+	}
+	fmt.Fprintf(&sql, "ON %s USING btree (%s);", pgx.Identifier{i.Schema, i.Table}.Sanitize(), pgx.Identifier{i.Column}.Sanitize())
+	return sql.String()
+}
+
+type Advice struct {
+	Query      string
+	CostBefore float64
+	CostAfter  float64
+	Indexes    []Index
+}
+
+func (a Advice) Improvement() float64 {
+	if a.CostBefore == 0 {
+		return 0
+	}
+	return (a.CostBefore - a.CostAfter) / a.CostBefore * 100
+}
+
+func Run(ctx context.Context, file string, limit uint, minImprovement float64, migrationName string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	queries, err := loadQueries(ctx, file, limit, conn, fsys)
+	if err != nil {
+		return err
+	}
+	advice, err := Advise(ctx, queries, conn)
+	if err != nil {
+		return err
+	}
+	var result []Advice
+	for _, a := range advice {
+		if len(a.Indexes) > 0 && a.Improvement() >= minImprovement {
+			result = append(result, a)
+		}
+	}
+	if err := printAdvice(ctx, result); err != nil {
+		return err
+	}
+	if len(migrationName) > 0 && len(result) > 0 {
+		return saveMigration(migrationName, result, fsys)
+	}
+	return nil
+}
+
+func loadQueries(ctx context.Context, file string, limit uint, conn *pgx.Conn, fsys afero.Fs) ([]string, error) {
+	if len(file) > 0 {
+		f, err := fsys.Open(file)
+		if err != nil {
+			return nil, errors.Errorf("failed to open query file: %w", err)
+		}
+		defer f.Close()
+		return parser.SplitAndTrim(f)
+	}
+	rows, err := conn.Query(ctx, TopQueriesQuery, limit)
+	if err != nil {
+		return nil, errors.Errorf("failed to query rows: %w", err)
+	}
+	return pgxv5.CollectStrings(rows)
+}
+
+// Advise evaluates candidate indexes for each query using hypothetical indexes.
+// All changes are made in a transaction that is rolled back before returning,
+// except hypothetical indexes which are reset after each query.
+func Advise(ctx context.Context, queries []string, conn *pgx.Conn) ([]Advice, error) {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, errors.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(context.Background()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
+	var version string
+	if err := tx.QueryRow(ctx, ServerVersion).Scan(&version); err != nil {
+		return nil, errors.Errorf("failed to get server version: %w", err)
+	}
+	versionNum, err := strconv.Atoi(version)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse server version: %w", err)
+	}
+	e := explainer{genericPlan: versionNum >= 160000}
+	if !e.genericPlan {
+		if _, err := tx.Exec(ctx, ForceGenericPlan); err != nil {
+			return nil, errors.Errorf("failed to set plan cache mode: %w", err)
+		}
+	}
+	if _, err := tx.Exec(ctx, CreateHypopg); err != nil {
+		return nil, errors.Errorf("failed to create hypopg extension: %w", err)
+	}
+	var result []Advice
+	for _, q := range queries {
+		if len(strings.TrimSpace(q)) == 0 {
+			continue
+		}
+		a, err := e.adviseQuery(ctx, tx, q)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Skipping query:", err)
+			continue
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+// adviseQuery runs in a savepoint so that a failing query does not abort the
+// outer transaction. Hypothetical indexes are backend local and survive the
+// rollback, so they are reset separately to not affect the next query.
+func (e *explainer) adviseQuery(ctx context.Context, parent pgx.Tx, query string) (Advice, error) {
+	result := Advice{Query: query}
+	tx, err := parent.Begin(ctx)
+	if err != nil {
+		return result, errors.Errorf("failed to create savepoint: %w", err)
+	}
+	candidates := map[string]Index{}
+	defer func() {
+		if err := tx.Rollback(context.Background()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		if len(candidates) == 0 {
+			return
+		}
+		if _, err := parent.Exec(context.Background(), ResetHypopg); err != nil {
+			fmt.Fprintln(os.Stderr, "failed to reset hypothetical indexes:", err)
+		}
+	}()
+	before, err := e.explain(ctx, tx, query)
+	if err != nil {
+		return result, err
+	}
+	result.CostBefore = before.Plan.TotalCost
+	// Create a hypothetical index on every candidate column of scanned relations
+	for _, rel := range utils.RemoveDuplicates(before.Plan.relations()) {
+		rows, err := tx.Query(ctx, ColumnsQuery, rel.schema, rel.name, reset.LikeEscapeSchema(utils.InternalSchemas))
+		if err != nil {
+			return result, errors.Errorf("failed to query columns: %w", err)
+		}
+		columns, err := pgxv5.CollectStrings(rows)
+		if err != nil {
+			return result, err
+		}
+		for _, col := range columns {
+			def := fmt.Sprintf("CREATE INDEX ON %s USING btree (%s)", pgx.Identifier{rel.schema, rel.name}.Sanitize(), pgx.Identifier{col}.Sanitize())
+			var name string
+			if err := tx.QueryRow(ctx, CreateIndex, def).Scan(&name); err != nil {
+				return result, errors.Errorf("failed to create hypothetical index: %w", err)
+			}
+			candidates[name] = Index{Schema: rel.schema, Table: rel.name, Column: col}
+		}
+	}
+	if len(candidates) == 0 {
+		result.CostAfter = result.CostBefore
+		return result, nil
+	}
+	after, err := e.explain(ctx, tx, query)
+	if err != nil {
+		return result, err
+	}
+	result.CostAfter = after.Plan.TotalCost
+	if result.CostAfter < result.CostBefore {
+		for _, name := range utils.RemoveDuplicates(after.Plan.indexes()) {
+			if index, ok := candidates[name]; ok {
+				result.Indexes = append(result.Indexes, index)
+			}
+		}
+	}
+	return result, nil
+}
+
+type plan struct {
+	NodeType     string  `json:"Node Type"`
+	TotalCost    float64 `json:"Total Cost"`
+	Schema       string  `json:"Schema"`
+	RelationName string  `json:"Relation Name"`
+	IndexName    string  `json:"Index Name"`
+	Plans        []plan  `json:"Plans"`
+}
+
+type relation struct {
+	schema string
+	name   string
+}
+
+func (p plan) relations() (result []relation) {
+	if len(p.RelationName) > 0 {
+		result = append(result, relation{schema: p.Schema, name: p.RelationName})
+	}
+	for _, child := range p.Plans {
+		result = append(result, child.relations()...)
+	}
+	return result
+}
+
+func (p plan) indexes() (result []string) {
+	if len(p.IndexName) > 0 {
+		result = append(result, p.IndexName)
+	}
+	for _, child := range p.Plans {
+		result = append(result, child.indexes()...)
+	}
+	return result
+}
+
+var paramPattern = regexp.MustCompile(`\$(\d+)`)
+
+// countParams returns the highest parameter number in a normalised query.
+func countParams(query string) (count int) {
+	for _, m := range paramPattern.FindAllStringSubmatch(query, -1) {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			count = max(count, n)
+		}
+	}
+	return count
+}
+
+type explainResult struct {
+	Plan plan
+}
+
+type explainer struct {
+	genericPlan bool
+	// Prepared statements outlive savepoints, so each one gets a unique name
+	prepared int
+}
+
+func (e *explainer) explain(ctx context.Context, tx pgx.Tx, query string) (result explainResult, err error) {
+	options := "FORMAT JSON, VERBOSE"
+	stmt := query
+	// Normalised queries from pg_stat_statements require a generic plan
+	if params := countParams(query); params > 0 {
+		if e.genericPlan {
+			options += ", GENERIC_PLAN"
+		} else {
+			e.prepared++
+			name := fmt.Sprintf("index_advisor_%d", e.prepared)
+			if _, err := tx.Exec(ctx, fmt.Sprintf("PREPARE %s AS %s", name, query)); err != nil {
+				return result, errors.Errorf("failed to prepare query: %w", err)
+			}
+			args := strings.TrimSuffix(strings.Repeat("NULL, ", params), ", ")
+			stmt = fmt.Sprintf("EXECUTE %s(%s)", name, args)
+		}
+	}
+	var data []byte
+	if err := tx.QueryRow(ctx, fmt.Sprintf("EXPLAIN (%s) %s", options, stmt)).Scan(&data); err != nil {
+		return result, errors.Errorf("failed to explain query: %w", err)
+	}
+	var plans []explainResult
+	if err := json.Unmarshal(data, &plans); err != nil {
+		return result, errors.Errorf("failed to parse query plan: %w", err)
+	} else if len(plans) == 0 {
+		return result, errors.New("empty query plan")
+	}
+	return plans[0], nil
+}
+
+type adviceRow struct {
+	Query       string  `db:"query"`
+	CostBefore  float64 `db:"cost_before"`
+	CostAfter   float64 `db:"cost_after"`
+	Improvement float64 `db:"improvement"`
+	Indexes     string  `db:"indexes"`
+}
+
+func printAdvice(ctx context.Context, advice []Advice) error {
+	if len(advice) == 0 && output.Format.Value == output.OutputTable {
+		fmt.Fprintln(os.Stderr, "No index recommendations found.")
+		return nil
+	}
+	rows := []adviceRow{}
+	re := regexp.MustCompile(`\s+`)
+	table := "|query|cost before|cost after|improvement|indexes|\n|-|-|-|-|-|\n"
+	var all []string
+	for _, a := range advice {
+		query := strings.ReplaceAll(re.ReplaceAllString(a.Query, " "), "|", `\|`)
+		var defs, indexes []string
+		for _, index := range a.Indexes {
+			defs = append(defs, index.SQL(true))
+			indexes = append(indexes, "`"+index.SQL(true)+"`")
+		}
+		all = append(all, defs...)
+		table += fmt.Sprintf("|%s|`%.2f`|`%.2f`|`%.1f%%`|%s|\n", query, a.CostBefore, a.CostAfter, a.Improvement(), strings.Join(indexes, "<br>"))
+		rows = append(rows, adviceRow{
+			Query:       a.Query,
+			CostBefore:  a.CostBefore,
+			CostAfter:   a.CostAfter,
+			Improvement: a.Improvement(),
+			Indexes:     strings.Join(defs, "\n"),
+		})
+	}
+	table += "\n```sql\n" + strings.Join(utils.RemoveDuplicates(all), "\n") + "\n```\n"
+	return output.Write(ctx, rows, table)
+}
+
+// saveMigration writes the recommended indexes without CONCURRENTLY because
+// migrations are applied in a transaction.
+func saveMigration(name string, advice []Advice, fsys afero.Fs) error {
+	var defs []string
+	for _, a := range advice {
+		for _, index := range a.Indexes {
+			defs = append(defs, index.SQL(false))
+		}
+	}
+	sort.Strings(defs)
+	path := new.GetMigrationPath(utils.GetCurrentTimestamp(), name)
+	contents := "-- Indexes recommended by supabase inspect db index-advisor\n" + strings.Join(utils.RemoveDuplicates(defs), "\n") + "\n"
+	if err := utils.WriteFile(path, []byte(contents), fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Created new migration at "+utils.Bold(path))
+	return nil
+}
@@ -0,0 +1,143 @@
+package index_advisor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+const (
+	seqScanPlan   = `[{"Plan": {"Node Type": "Seq Scan", "Total Cost": 100, "Schema": "public", "Relation Name": "todos"}}]`
+	indexScanPlan = `[{"Plan": {"Node Type": "Index Scan", "Total Cost": 8, "Schema": "public", "Relation Name": "todos", "Index Name": "<13543>btree_public_todos_user_id"}}]`
+)
+
+func TestIndexAdvisorCommand(t *testing.T) {
+	query := "select * from todos where user_id = $1"
+
+	t.Run("recommends index from query file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "queries.sql", []byte(query+";\nselect 1"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(ServerVersion).Reply("SHOW", []any{"160004"}).
+			Query(CreateHypopg).Reply("CREATE EXTENSION").
+			Query("savepoint sp_1").Reply("SAVEPOINT").
+			Query("EXPLAIN (FORMAT JSON, VERBOSE, GENERIC_PLAN) "+query).
+			Reply("EXPLAIN", []any{seqScanPlan}).
+			Query(ColumnsQuery, "public", "todos", reset.LikeEscapeSchema(utils.InternalSchemas)).
+			Reply("SELECT 1", []any{"user_id"}).
+			Query(CreateIndex, `CREATE INDEX ON "public"."todos" USING btree ("user_id")`).
+			Reply("SELECT 1", []any{"<13543>btree_public_todos_user_id"}).
+			Query("EXPLAIN (FORMAT JSON, VERBOSE, GENERIC_PLAN) "+query).
+			Reply("EXPLAIN", []any{indexScanPlan}).
+			Query("rollback to savepoint sp_1").Reply("ROLLBACK").
+			Query(ResetHypopg).Reply("SELECT 1").
+			Query("savepoint sp_2").Reply("SAVEPOINT").
+			Query("EXPLAIN (FORMAT JSON, VERBOSE) select 1").
+			Reply("EXPLAIN", []any{`[{"Plan": {"Node Type": "Result", "Total Cost": 0.01}}]`}).
+			Query("rollback to savepoint sp_2").Reply("ROLLBACK").
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		err := Run(context.Background(), "queries.sql", 10, 10, "add_indexes", dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		matches, err := afero.Glob(fsys, "supabase/migrations/*_add_indexes.sql")
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		contents, err := afero.ReadFile(fsys, matches[0])
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), `CREATE INDEX ON "public"."todos" USING btree ("user_id");`)
+	})
+
+	t.Run("prepares generic plan before postgres 16", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(ServerVersion).Reply("SHOW", []any{"150008"}).
+			Query(ForceGenericPlan).Reply("SET").
+			Query(CreateHypopg).Reply("CREATE EXTENSION").
+			Query("savepoint sp_1").Reply("SAVEPOINT").
+			Query("PREPARE index_advisor_1 AS select * from todos where user_id = $1 and done = $2").Reply("PREPARE").
+			Query("EXPLAIN (FORMAT JSON, VERBOSE) EXECUTE index_advisor_1(NULL, NULL)").
+			Reply("EXPLAIN", []any{seqScanPlan}).
+			Query(ColumnsQuery, "public", "todos", reset.LikeEscapeSchema(utils.InternalSchemas)).
+			Reply("SELECT 0").
+			Query("rollback to savepoint sp_1").Reply("ROLLBACK").
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		advice, err := Advise(context.Background(), []string{"select * from todos where user_id = $1 and done = $2"}, conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, advice, 1)
+		assert.Empty(t, advice[0].Indexes)
+	})
+
+	t.Run("resets hypothetical indexes on failed query", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(ServerVersion).Reply("SHOW", []any{"160004"}).
+			Query(CreateHypopg).Reply("CREATE EXTENSION").
+			Query("savepoint sp_1").Reply("SAVEPOINT").
+			Query("EXPLAIN (FORMAT JSON, VERBOSE, GENERIC_PLAN) "+query).
+			Reply("EXPLAIN", []any{seqScanPlan}).
+			Query(ColumnsQuery, "public", "todos", reset.LikeEscapeSchema(utils.InternalSchemas)).
+			Reply("SELECT 1", []any{"user_id"}).
+			Query(CreateIndex, `CREATE INDEX ON "public"."todos" USING btree ("user_id")`).
+			Reply("SELECT 1", []any{"<13543>btree_public_todos_user_id"}).
+			Query("EXPLAIN (FORMAT JSON, VERBOSE, GENERIC_PLAN) "+query).
+			ReplyError(pgerrcode.QueryCanceled, "canceling statement due to statement timeout").
+			Query("rollback to savepoint sp_1").Reply("ROLLBACK").
+			Query(ResetHypopg).Reply("SELECT 1").
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		advice, err := Advise(context.Background(), []string{query}, conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, advice)
+	})
+
+	t.Run("throws error if hypopg is unavailable", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(TopQueriesQuery, 5).
+			Reply("SELECT 1", []any{query}).
+			Query("begin").Reply("BEGIN").
+			Query(ServerVersion).Reply("SHOW", []any{"160004"}).
+			Query(CreateHypopg).
+			ReplyError(pgerrcode.FeatureNotSupported, `extension "hypopg" is not available`).
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		err := Run(context.Background(), "", 5, 10, "", dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, `failed to create hypopg extension: ERROR: extension "hypopg" is not available`)
+	})
+}
+
+func TestIndexSQL(t *testing.T) {
+	index := Index{Schema: "public", Table: "Todos", Column: "user_id"}
+	assert.Equal(t, `CREATE INDEX CONCURRENTLY ON "public"."Todos" USING btree ("user_id");`, index.SQL(true))
+	assert.Equal(t, `CREATE INDEX ON "public"."Todos" USING btree ("user_id");`, index.SQL(false))
+}
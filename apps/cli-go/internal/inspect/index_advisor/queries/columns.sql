@@ -0,0 +1,16 @@
+-- Candidate columns that are not already the leading column of an index
+SELECT a.attname
+FROM pg_attribute a
+JOIN pg_class c ON c.oid = a.attrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_type t ON t.oid = a.atttypid
+WHERE n.nspname = $1 AND c.relname = $2
+AND NOT n.nspname LIKE ANY($3)
+AND c.relkind IN ('r', 'm', 'p')
+AND a.attnum > 0 AND NOT a.attisdropped
+AND t.typcategory NOT IN ('A', 'G')
+AND t.typname NOT IN ('json', 'jsonb', 'xml', 'tsvector', 'bytea')
+AND NOT EXISTS (
+  SELECT 1 FROM pg_index i WHERE i.indrelid = c.oid AND i.indkey[0] = a.attnum
+)
+ORDER BY a.attnum
@@ -0,0 +1,9 @@
+SELECT s.query
+FROM extensions.pg_stat_statements s
+JOIN pg_database d ON d.oid = s.dbid
+WHERE d.datname = current_database()
+AND s.query ~* '^\s*(select|with|update|delete)\y'
+AND s.query !~* '\y(pg_catalog|information_schema|pg_stat_statements|hypopg)'
+GROUP BY s.query
+ORDER BY sum(s.total_exec_time) DESC
+LIMIT $1
@@ -20,7 +20,8 @@ SELECT
       )
     )
   )::text AS sync_io_time,
-  query
+  query,
+  queryid::text AS queryid
 FROM extensions.pg_stat_statements s WHERE userid = (SELECT usesysid FROM pg_user WHERE usename = current_user LIMIT 1)
 ORDER BY total_exec_time DESC
 LIMIT 10
@@ -0,0 +1,167 @@
+package output
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	_ "github.com/mithrandie/csvq-driver"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgxv5"
+)
+
+const (
+	OutputTable = "table"
+	OutputCsv   = "csv"
+)
+
+// Format and FailIf are shared by all inspect db subcommands.
+var (
+	Format = utils.EnumFlag{
+		Allowed: []string{OutputTable, utils.OutputJson, OutputCsv},
+		Value:   OutputTable,
+	}
+	FailIf string
+)
+
+// Write prints rows in the selected output format, using the pre-rendered
+// markdown table by default. If --fail-if is set, an error is returned when
+// any row matches the expression.
+func Write[T any](ctx context.Context, rows []T, table string) error {
+	header, kinds, records := toRecords(rows)
+	if err := printRows(header, kinds, records, table, os.Stdout); err != nil {
+		return err
+	}
+	if len(FailIf) == 0 {
+		return nil
+	}
+	matches, err := countMatches(ctx, FailIf, header, records)
+	if err != nil {
+		return err
+	} else if matches > 0 {
+		return errors.Errorf("%d rows matched --fail-if %q", matches, FailIf)
+	}
+	return nil
+}
+
+func printRows(header []string, kinds []reflect.Kind, records [][]string, table string, w io.Writer) error {
+	switch Format.Value {
+	case utils.OutputJson:
+		result := make([]map[string]any, len(records))
+		for i, r := range records {
+			result[i] = make(map[string]any, len(header))
+			for j, h := range header {
+				result[i][h] = toJsonValue(r[j], kinds[j])
+			}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return errors.Errorf("failed to encode json: %w", err)
+		}
+		return nil
+	case OutputCsv:
+		return writeCsv(header, records, w)
+	}
+	return utils.RenderTable(table)
+}
+
+// toJsonValue keeps numeric and boolean columns typed in json output. The
+// type is taken from the struct field, so numeric text such as queryid stays
+// a string without losing precision.
+func toJsonValue(value string, kind reflect.Kind) any {
+	switch kind {
+	case reflect.Bool:
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return v
+		}
+	case reflect.Float32, reflect.Float64:
+		// NaN and Inf are not valid json numbers
+		if v, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(v) && !math.IsInf(v, 0) {
+			return v
+		}
+	}
+	return value
+}
+
+// toRecords flattens struct rows using the same column names as the queries.
+func toRecords[T any](rows []T) (header []string, kinds []reflect.Kind, records [][]string) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	var fields []int
+	for i := 0; i < t.NumField(); i++ {
+		if name := pgxv5.GetColumnName(t.Field(i)); len(name) > 0 && t.Field(i).IsExported() {
+			header = append(header, strings.ToLower(name))
+			kinds = append(kinds, t.Field(i).Type.Kind())
+			fields = append(fields, i)
+		}
+	}
+	for _, r := range rows {
+		v := reflect.ValueOf(r)
+		record := make([]string, len(fields))
+		for j, i := range fields {
+			record[j] = fmt.Sprint(v.Field(i).Interface())
+		}
+		records = append(records, record)
+	}
+	return header, kinds, records
+}
+
+func writeCsv(header []string, records [][]string, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return errors.Errorf("failed to write csv: %w", err)
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return errors.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
+
+// countMatches evaluates expr as a filter over the result rows using csvq, the
+// same engine that evaluates inspect report rules.
+func countMatches(ctx context.Context, expr string, header []string, records [][]string) (int, error) {
+	tmpDir, err := os.MkdirTemp("", "supabase-inspect-")
+	if err != nil {
+		return 0, errors.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	f, err := os.Create(filepath.Join(tmpDir, "result.csv"))
+	if err != nil {
+		return 0, errors.Errorf("failed to create result file: %w", err)
+	}
+	err = writeCsv(header, records, f)
+	if cerr := f.Close(); err == nil && cerr != nil {
+		err = errors.Errorf("failed to close result file: %w", cerr)
+	}
+	if err != nil {
+		return 0, err
+	}
+	db, err := sql.Open("csvq", tmpDir)
+	if err != nil {
+		return 0, errors.Errorf("failed to open csvq: %w", err)
+	}
+	defer db.Close()
+	var count int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM `result.csv` WHERE "+expr).Scan(&count); err != nil {
+		return 0, errors.Errorf("failed to evaluate --fail-if: %w", err)
+	}
+	return count, nil
+}
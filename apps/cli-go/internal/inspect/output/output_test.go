@@ -0,0 +1,98 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type row struct {
+	Name      string
+	Queryid   string
+	Seq_scans int64
+	Ratio     float64 `db:"hit_ratio"`
+	Valid     bool
+	hidden    string
+}
+
+var rows = []row{
+	{Name: "public.todos", Queryid: "-8674924386279452651", Seq_scans: 1000, Ratio: 0.5, Valid: true},
+	{Name: "public.users", Queryid: "9223372036854775807", Seq_scans: 10, Ratio: 0.99},
+}
+
+func TestPrintRows(t *testing.T) {
+	header, kinds, records := toRecords(rows)
+	assert.Equal(t, []string{"name", "queryid", "seq_scans", "hit_ratio", "valid"}, header)
+
+	t.Run("prints json with typed values", func(t *testing.T) {
+		Format.Value = utils.OutputJson
+		t.Cleanup(func() { Format.Value = OutputTable })
+		var buf bytes.Buffer
+		// Run test
+		err := printRows(header, kinds, records, "", &buf)
+		// Check error
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"name": "public.todos", "queryid": "-8674924386279452651", "seq_scans": 1000, "hit_ratio": 0.5, "valid": true},
+			{"name": "public.users", "queryid": "9223372036854775807", "seq_scans": 10, "hit_ratio": 0.99, "valid": false}
+		]`, buf.String())
+	})
+
+	t.Run("prints csv with header", func(t *testing.T) {
+		Format.Value = OutputCsv
+		t.Cleanup(func() { Format.Value = OutputTable })
+		var buf bytes.Buffer
+		// Run test
+		err := printRows(header, kinds, records, "", &buf)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `name,queryid,seq_scans,hit_ratio,valid
+public.todos,-8674924386279452651,1000,0.5,true
+public.users,9223372036854775807,10,0.99,false
+`, buf.String())
+	})
+
+	t.Run("prints empty json array", func(t *testing.T) {
+		Format.Value = utils.OutputJson
+		t.Cleanup(func() { Format.Value = OutputTable })
+		header, kinds, records := toRecords([]row{})
+		var buf bytes.Buffer
+		// Run test
+		err := printRows(header, kinds, records, "", &buf)
+		// Check error
+		assert.NoError(t, err)
+		assert.JSONEq(t, "[]", buf.String())
+	})
+}
+
+func TestFailIf(t *testing.T) {
+	Format.Value = OutputCsv
+	t.Cleanup(func() { Format.Value = OutputTable; FailIf = "" })
+
+	t.Run("throws error on matching rows", func(t *testing.T) {
+		FailIf = "seq_scans > 100 AND hit_ratio < 0.9"
+		// Run test
+		err := Write(context.Background(), rows, "")
+		// Check error
+		assert.ErrorContains(t, err, `1 rows matched --fail-if "seq_scans > 100 AND hit_ratio < 0.9"`)
+	})
+
+	t.Run("passes when no rows match", func(t *testing.T) {
+		FailIf = "seq_scans > 10000"
+		// Run test
+		err := Write(context.Background(), rows, "")
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on invalid expression", func(t *testing.T) {
+		FailIf = "unknown_column > 1"
+		// Run test
+		err := Write(context.Background(), rows, "")
+		// Check error
+		assert.ErrorContains(t, err, "failed to evaluate --fail-if:")
+	})
+}
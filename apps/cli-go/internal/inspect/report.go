@@ -93,15 +93,33 @@ func printSummary(ctx context.Context, outDir string) error {
 			return errors.Errorf("failed load default rules: %w", err)
 		}
 	}
-	// Open csvq database rooted at the output directory
-	db, err := sql.Open("csvq", outDir)
+	var rules []reportRule
+	for _, r := range utils.Config.Experimental.Inspect.Rules {
+		rules = append(rules, reportRule{Query: r.Query, Name: r.Name, Pass: r.Pass, Fail: r.Fail})
+	}
+	results, err := evaluateRules(ctx, outDir, rules)
 	if err != nil {
 		return err
 	}
+	return utils.RenderTable(rulesToMarkdown(results))
+}
+
+type reportRule struct {
+	Query string
+	Name  string
+	Pass  string
+	Fail  string
+}
+
+func evaluateRules(ctx context.Context, dir string, rules []reportRule) ([]RuleResult, error) {
+	// Open csvq database rooted at the output directory
+	db, err := sql.Open("csvq", dir)
+	if err != nil {
+		return nil, err
+	}
 	defer db.Close()
-	// Build report summary table
-	table := "RULE|STATUS|MATCHES\n|-|-|-|\n"
-	for _, r := range utils.Config.Experimental.Inspect.Rules {
+	var results []RuleResult
+	for _, r := range rules {
 		row := db.QueryRowContext(ctx, r.Query)
 		// find matching rule
 		var status string
@@ -118,7 +136,16 @@ func printSummary(ctx context.Context, outDir string) error {
 		if !match.Valid {
 			match.String = "-"
 		}
-		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", r.Name, status, match.String)
+		results = append(results, RuleResult{Name: r.Name, Status: status, Matches: match.String})
+	}
+	return results, nil
+}
+
+func rulesToMarkdown(results []RuleResult) string {
+	// Build report summary table
+	table := "RULE|STATUS|MATCHES\n|-|-|-|\n"
+	for _, r := range results {
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", r.Name, r.Status, r.Matches)
 	}
-	return utils.RenderTable(table)
+	return table
 }
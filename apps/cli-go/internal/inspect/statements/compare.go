@@ -0,0 +1,146 @@
+package statements
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/inspect/output"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Delta holds per-queryid changes between two snapshots. Mean times are in
+// milliseconds: OldMean is the cumulative mean at the first snapshot and
+// NewMean is the mean of calls made between the two snapshots.
+type Delta struct {
+	Queryid    string  `db:"queryid"`
+	Query      string  `db:"query"`
+	Calls      int64   `db:"calls"`
+	Rows       int64   `db:"rows"`
+	BlksHit    int64   `db:"shared_blks_hit"`
+	BlksRead   int64   `db:"shared_blks_read"`
+	OldMean    float64 `db:"mean_exec_time_before"`
+	NewMean    float64 `db:"mean_exec_time_after"`
+	Percent    float64 `db:"percent_change"`
+	Regression bool    `db:"regression"`
+}
+
+// Compare diffs two snapshots and returns an error if any query regressed by
+// more than threshold percent. With no names, the latest two snapshots are
+// compared. With one name, it is compared against the latest snapshot.
+func Compare(ctx context.Context, names []string, threshold float64, fsys afero.Fs) error {
+	oldPath, newPath, err := pickSnapshots(names, fsys)
+	if err != nil {
+		return err
+	}
+	before, err := LoadSnapshot(oldPath, fsys)
+	if err != nil {
+		return err
+	}
+	after, err := LoadSnapshot(newPath, fsys)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Comparing %s to %s\n", utils.Bold(oldPath), utils.Bold(newPath))
+	deltas := ComputeDeltas(before, after, threshold)
+	if err := printDeltas(ctx, deltas); err != nil {
+		return err
+	}
+	var regressions int
+	for _, d := range deltas {
+		if d.Regression {
+			regressions++
+		}
+	}
+	if regressions > 0 {
+		return errors.Errorf("found %d queries with mean time regressed by more than %g%%", regressions, threshold)
+	}
+	return nil
+}
+
+func pickSnapshots(names []string, fsys afero.Fs) (string, string, error) {
+	if len(names) == 2 {
+		return resolveSnapshot(names[0], fsys), resolveSnapshot(names[1], fsys), nil
+	}
+	paths, err := ListSnapshots(fsys)
+	if err != nil {
+		return "", "", err
+	}
+	if len(names) == 1 {
+		if len(paths) == 0 {
+			return "", "", errors.New("no snapshots found: run " + utils.Aqua("supabase inspect db statements snapshot") + " first")
+		}
+		return resolveSnapshot(names[0], fsys), paths[len(paths)-1], nil
+	}
+	if len(paths) < 2 {
+		return "", "", errors.Errorf("found %d snapshots: at least 2 are required", len(paths))
+	}
+	return paths[len(paths)-2], paths[len(paths)-1], nil
+}
+
+func ComputeDeltas(before, after Snapshot, threshold float64) []Delta {
+	index := make(map[string]Statement, len(before.Statements))
+	for _, s := range before.Statements {
+		index[s.Queryid] = s
+	}
+	var result []Delta
+	for _, s := range after.Statements {
+		prev := index[s.Queryid]
+		// Counters start from 0 after pg_stat_statements_reset
+		if s.Calls < prev.Calls {
+			prev = Statement{}
+		}
+		d := Delta{
+			Queryid:  s.Queryid,
+			Query:    s.Query,
+			Calls:    s.Calls - prev.Calls,
+			Rows:     s.Rows - prev.Rows,
+			BlksHit:  s.Shared_blks_hit - prev.Shared_blks_hit,
+			BlksRead: s.Shared_blks_read - prev.Shared_blks_read,
+			OldMean:  prev.MeanExecTime(),
+		}
+		if d.Calls == 0 {
+			continue
+		}
+		d.NewMean = (s.Total_exec_time - prev.Total_exec_time) / float64(d.Calls)
+		if d.OldMean > 0 {
+			d.Percent = (d.NewMean - d.OldMean) / d.OldMean * 100
+			d.Regression = d.Percent > threshold
+		}
+		result = append(result, d)
+	}
+	// Show regressions first, then the most called queries
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Regression != result[j].Regression {
+			return result[i].Regression
+		}
+		if result[i].Percent != result[j].Percent {
+			return result[i].Percent > result[j].Percent
+		}
+		return result[i].Calls > result[j].Calls
+	})
+	return result
+}
+
+func printDeltas(ctx context.Context, deltas []Delta) error {
+	re := regexp.MustCompile(`\s+`)
+	table := "|queryid|calls|mean time before|mean time after|change|rows|shared blks hit|shared blks read|query|\n|-|-|-|-|-|-|-|-|-|\n"
+	for _, d := range deltas {
+		change := "new"
+		if d.OldMean > 0 {
+			change = fmt.Sprintf("%+.1f%%", d.Percent)
+		}
+		if d.Regression {
+			change += " ⚠"
+		}
+		query := re.ReplaceAllString(d.Query, " ")
+		query = regexp.MustCompile(`\|`).ReplaceAllString(query, `\|`)
+		table += fmt.Sprintf("|`%s`|`%d`|`%.2fms`|`%.2fms`|`%s`|`%d`|`%d`|`%d`|%s|\n",
+			d.Queryid, d.Calls, d.OldMean, d.NewMean, change, d.Rows, d.BlksHit, d.BlksRead, query)
+	}
+	return output.Write(ctx, deltas, table)
+}
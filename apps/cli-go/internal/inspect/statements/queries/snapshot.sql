@@ -0,0 +1,12 @@
+SELECT
+  queryid::text AS queryid,
+  min(query) AS query,
+  sum(calls)::int8 AS calls,
+  sum(total_exec_time)::float8 AS total_exec_time,
+  sum(rows)::int8 AS rows,
+  sum(shared_blks_hit)::int8 AS shared_blks_hit,
+  sum(shared_blks_read)::int8 AS shared_blks_read
+FROM extensions.pg_stat_statements
+WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+AND queryid IS NOT NULL
+GROUP BY queryid
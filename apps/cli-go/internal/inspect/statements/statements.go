@@ -0,0 +1,106 @@
+package statements
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgxv5"
+)
+
+// Nested under queries so that inspect report does not pick it up.
+//
+//go:embed queries/snapshot.sql
+var SnapshotQuery string
+
+type Statement struct {
+	Queryid          string  `json:"queryid"`
+	Query            string  `json:"query"`
+	Calls            int64   `json:"calls"`
+	Total_exec_time  float64 `json:"total_exec_time"`
+	Rows             int64   `json:"rows"`
+	Shared_blks_hit  int64   `json:"shared_blks_hit"`
+	Shared_blks_read int64   `json:"shared_blks_read"`
+}
+
+func (s Statement) MeanExecTime() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Total_exec_time / float64(s.Calls)
+}
+
+type Snapshot struct {
+	Timestamp  time.Time   `json:"timestamp"`
+	Statements []Statement `json:"statements"`
+}
+
+const snapshotLayout = "20060102150405"
+
+func Save(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	rows, err := conn.Query(ctx, SnapshotQuery)
+	if err != nil {
+		return errors.Errorf("failed to query rows: %w", err)
+	}
+	result, err := pgxv5.CollectRows[Statement](rows)
+	if err != nil {
+		return err
+	}
+	snapshot := Snapshot{Timestamp: time.Now().UTC(), Statements: result}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return errors.Errorf("failed to encode snapshot: %w", err)
+	}
+	path := filepath.Join(utils.StatementsDir, snapshot.Timestamp.Format(snapshotLayout)+".json")
+	if err := utils.WriteFile(path, data, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Saved snapshot of %d statements to %s\n", len(result), utils.Bold(path))
+	return nil
+}
+
+// ListSnapshots returns the paths of saved snapshots from oldest to newest.
+func ListSnapshots(fsys afero.Fs) ([]string, error) {
+	paths, err := afero.Glob(fsys, filepath.Join(utils.StatementsDir, "*.json"))
+	if err != nil {
+		return nil, errors.Errorf("failed to list snapshots: %w", err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func LoadSnapshot(path string, fsys afero.Fs) (Snapshot, error) {
+	var snapshot Snapshot
+	data, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return snapshot, errors.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, errors.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// resolveSnapshot accepts either a file path or the name of a saved snapshot.
+func resolveSnapshot(name string, fsys afero.Fs) string {
+	if ok, _ := afero.Exists(fsys, name); ok {
+		return name
+	}
+	return filepath.Join(utils.StatementsDir, strings.TrimSuffix(name, ".json")+".json")
+}
@@ -0,0 +1,123 @@
+package statements
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestSnapshotCommand(t *testing.T) {
+	t.Run("saves snapshot", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(SnapshotQuery).
+			Reply("SELECT 1", Statement{
+				Queryid:          "42",
+				Query:            "select $1",
+				Calls:            10,
+				Total_exec_time:  25,
+				Rows:             10,
+				Shared_blks_hit:  100,
+				Shared_blks_read: 1,
+			})
+		// Run test
+		err := Save(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		paths, err := ListSnapshots(fsys)
+		require.NoError(t, err)
+		require.Len(t, paths, 1)
+		snapshot, err := LoadSnapshot(paths[0], fsys)
+		assert.NoError(t, err)
+		assert.Len(t, snapshot.Statements, 1)
+		assert.Equal(t, 2.5, snapshot.Statements[0].MeanExecTime())
+	})
+}
+
+func writeSnapshot(t *testing.T, fsys afero.Fs, name, contents string) {
+	path := filepath.Join(utils.StatementsDir, name+".json")
+	require.NoError(t, afero.WriteFile(fsys, path, []byte(contents), 0644))
+}
+
+func TestCompareCommand(t *testing.T) {
+	t.Run("computes deltas between snapshots", func(t *testing.T) {
+		before := Snapshot{Statements: []Statement{
+			{Queryid: "1", Calls: 10, Total_exec_time: 10, Rows: 10},
+			{Queryid: "2", Calls: 10, Total_exec_time: 100},
+			{Queryid: "3", Calls: 5, Total_exec_time: 5},
+		}}
+		after := Snapshot{Statements: []Statement{
+			// 10 calls averaging 3ms each
+			{Queryid: "1", Calls: 20, Total_exec_time: 40, Rows: 30},
+			// Counters were reset
+			{Queryid: "2", Calls: 2, Total_exec_time: 10},
+			// No new calls
+			{Queryid: "3", Calls: 5, Total_exec_time: 5},
+			{Queryid: "4", Calls: 1, Total_exec_time: 1},
+		}}
+		// Run test
+		deltas := ComputeDeltas(before, after, 20)
+		// Check result
+		require.Len(t, deltas, 3)
+		assert.Equal(t, Delta{
+			Queryid:    "1",
+			Calls:      10,
+			Rows:       20,
+			OldMean:    1,
+			NewMean:    3,
+			Percent:    200,
+			Regression: true,
+		}, deltas[0])
+		assert.Equal(t, "2", deltas[1].Queryid)
+		assert.Equal(t, int64(2), deltas[1].Calls)
+		assert.False(t, deltas[1].Regression)
+		assert.Equal(t, "4", deltas[2].Queryid)
+	})
+
+	t.Run("throws error on regression", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		writeSnapshot(t, fsys, "20250101000000", `{"statements":[{"queryid":"1","calls":10,"total_exec_time":10}]}`)
+		writeSnapshot(t, fsys, "20250102000000", `{"statements":[{"queryid":"1","calls":20,"total_exec_time":30}]}`)
+		// Run test
+		err := Compare(context.Background(), nil, 50, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "found 1 queries with mean time regressed by more than 50%")
+	})
+
+	t.Run("passes below threshold", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		writeSnapshot(t, fsys, "20250101000000", `{"statements":[{"queryid":"1","calls":10,"total_exec_time":10}]}`)
+		writeSnapshot(t, fsys, "20250102000000", `{"statements":[{"queryid":"1","calls":20,"total_exec_time":21}]}`)
+		// Run test
+		err := Compare(context.Background(), []string{"20250101000000"}, 20, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing snapshots", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		writeSnapshot(t, fsys, "20250101000000", `{"statements":[]}`)
+		// Run test
+		err := Compare(context.Background(), nil, 20, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "found 1 snapshots: at least 2 are required")
+	})
+}
@@ -0,0 +1,314 @@
+package top
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type source interface {
+	Snapshot(ctx context.Context) (Snapshot, error)
+	Signal(ctx context.Context, terminate bool, pid int) error
+}
+
+type sortKey int
+
+const (
+	sortByDuration sortKey = iota
+	sortByPid
+	sortByRole
+	sortByState
+	numSortKeys
+)
+
+func (k sortKey) String() string {
+	switch k {
+	case sortByPid:
+		return "pid"
+	case sortByRole:
+		return "role"
+	case sortByState:
+		return "state"
+	default:
+		return "duration"
+	}
+}
+
+type (
+	snapshotMsg struct {
+		snapshot Snapshot
+		err      error
+	}
+	// tickMsg carries the id of the refresh loop that scheduled it, so that
+	// stale ticks are dropped after a manual refresh.
+	tickMsg   int
+	signalMsg struct {
+		pid       int
+		terminate bool
+		err       error
+	}
+)
+
+type pendingSignal struct {
+	pid       int
+	terminate bool
+}
+
+type model struct {
+	ctx      context.Context
+	source   source
+	interval time.Duration
+
+	snapshot Snapshot
+	err      error
+	loading  bool
+	tickID   int
+
+	sortBy    sortKey
+	filter    string
+	filtering bool
+	cursor    int
+	confirm   *pendingSignal
+	status    string
+
+	width int
+}
+
+func newModel(ctx context.Context, s source, interval time.Duration) model {
+	return model{ctx: ctx, source: s, interval: interval, loading: true}
+}
+
+func (m model) Init() tea.Cmd {
+	return m.refresh()
+}
+
+func (m model) refresh() tea.Cmd {
+	return func() tea.Msg {
+		s, err := m.source.Snapshot(m.ctx)
+		return snapshotMsg{snapshot: s, err: err}
+	}
+}
+
+func (m model) signal(p pendingSignal) tea.Cmd {
+	return func() tea.Msg {
+		err := m.source.Signal(m.ctx, p.terminate, p.pid)
+		return signalMsg{pid: p.pid, terminate: p.terminate, err: err}
+	}
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+	case snapshotMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.snapshot = msg.snapshot
+		}
+		m.clampCursor()
+		m.tickID++
+		id := m.tickID
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg {
+			return tickMsg(id)
+		})
+	case tickMsg:
+		if int(msg) != m.tickID || m.loading {
+			return m, nil
+		}
+		m.loading = true
+		return m, m.refresh()
+	case signalMsg:
+		if msg.err != nil {
+			m.status = msg.err.Error()
+		} else if msg.terminate {
+			m.status = fmt.Sprintf("Terminated backend %d", msg.pid)
+		} else {
+			m.status = fmt.Sprintf("Cancelled query on backend %d", msg.pid)
+		}
+		if m.loading {
+			return m, nil
+		}
+		m.loading = true
+		return m, m.refresh()
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+	if m.confirm != nil {
+		p := *m.confirm
+		m.confirm = nil
+		if msg.String() == "y" || msg.String() == "Y" {
+			return m, m.signal(p)
+		}
+		m.status = "Aborted"
+		return m, nil
+	}
+	if m.filtering {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.filtering = false
+		case tea.KeyEsc:
+			m.filtering = false
+			m.filter = ""
+		case tea.KeyBackspace:
+			if r := []rune(m.filter); len(r) > 0 {
+				m.filter = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.filter += string(msg.Runes)
+		}
+		m.clampCursor()
+		return m, nil
+	}
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		m.cursor++
+		m.clampCursor()
+	case "s":
+		m.sortBy = (m.sortBy + 1) % numSortKeys
+	case "/":
+		m.filtering = true
+	case "r":
+		if !m.loading {
+			m.loading = true
+			return m, m.refresh()
+		}
+	case "c", "t":
+		rows := m.rows()
+		if m.cursor < len(rows) {
+			m.confirm = &pendingSignal{pid: rows[m.cursor].Pid, terminate: msg.String() == "t"}
+		}
+	}
+	return m, nil
+}
+
+func (m *model) clampCursor() {
+	if n := len(m.rows()); m.cursor >= n {
+		m.cursor = max(n-1, 0)
+	}
+}
+
+// rows returns the active queries matching the current filter and sort order.
+func (m model) rows() []Activity {
+	filter := strings.ToLower(m.filter)
+	var result []Activity
+	for _, r := range m.snapshot.Activity {
+		if len(filter) == 0 || strings.Contains(strings.ToLower(strings.Join([]string{
+			fmt.Sprint(r.Pid), r.Usename, r.Application_name, r.State, r.Wait_event, r.Query,
+		}, " ")), filter) {
+			result = append(result, r)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		switch m.sortBy {
+		case sortByPid:
+			return a.Pid < b.Pid
+		case sortByRole:
+			return a.Usename < b.Usename
+		case sortByState:
+			return a.State < b.State
+		default:
+			return a.Duration > b.Duration
+		}
+	})
+	return result
+}
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+)
+
+func (m model) View() string {
+	var b strings.Builder
+	updated := "loading..."
+	if !m.snapshot.Timestamp.IsZero() {
+		updated = m.snapshot.Timestamp.Format(time.TimeOnly)
+	}
+	fmt.Fprintf(&b, "%s  refreshed %s every %s  cache hit %s\n",
+		headerStyle.Render("supabase inspect db top"), updated, m.interval, formatRatio(m.snapshot.CacheHit))
+	var roles []string
+	for _, r := range m.snapshot.Connections {
+		roles = append(roles, fmt.Sprintf("%s %d/%d", r.Role_name, r.Active, r.Total))
+	}
+	fmt.Fprintf(&b, "Connections (active/total): %s\n", strings.Join(roles, "  "))
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(m.err.Error()) + "\n")
+	}
+	// Active queries
+	rows := m.rows()
+	fmt.Fprintf(&b, "\n%s  sort: %s", headerStyle.Render(fmt.Sprintf("Active queries (%d)", len(rows))), m.sortBy)
+	if len(m.filter) > 0 || m.filtering {
+		fmt.Fprintf(&b, "  filter: %s", m.filter)
+		if m.filtering {
+			b.WriteString("_")
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(faintStyle.Render(fmt.Sprintf("%-8s %-16s %-20s %-20s %10s  %s", "PID", "ROLE", "STATE", "WAIT", "DURATION", "QUERY")) + "\n")
+	for i, r := range rows {
+		line := m.truncate(fmt.Sprintf("%-8d %-16s %-20s %-20s %10s  %s", r.Pid, clip(r.Usename, 16), clip(r.State, 20), clip(r.Wait_event, 20), formatDuration(r.Duration), compactQuery(r.Query)))
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	// Blocking chains
+	fmt.Fprintf(&b, "\n%s\n", headerStyle.Render(fmt.Sprintf("Blocking (%d)", len(m.snapshot.Blocking))))
+	for _, r := range m.snapshot.Blocking {
+		b.WriteString(m.truncate(fmt.Sprintf("%d (%s) blocked by %d (%s): %s", r.Blocked_pid, r.Blocked_duration, r.Blocking_pid, r.Blocking_duration, compactQuery(r.Blocking_statement))) + "\n")
+	}
+	// Exclusive locks
+	fmt.Fprintf(&b, "\n%s\n", headerStyle.Render(fmt.Sprintf("Locks (%d)", len(m.snapshot.Locks))))
+	for _, r := range m.snapshot.Locks {
+		b.WriteString(m.truncate(fmt.Sprintf("%-8d %-24s granted=%-5t %s  %s", r.Pid, clip(r.Relname, 24), r.Granted, r.Age, compactQuery(r.Stmt))) + "\n")
+	}
+	b.WriteString("\n")
+	if m.confirm != nil {
+		action := "Cancel query on"
+		if m.confirm.terminate {
+			action = "Terminate"
+		}
+		fmt.Fprintf(&b, "%s backend %d? [y/N]\n", action, m.confirm.pid)
+	} else if len(m.status) > 0 {
+		b.WriteString(m.status + "\n")
+	}
+	b.WriteString(faintStyle.Render("↑/↓ select • s sort • / filter • c cancel • t terminate • r refresh • q quit"))
+	return b.String()
+}
+
+func (m model) truncate(line string) string {
+	if r := []rune(line); m.width > 0 && len(r) > m.width {
+		return string(r[:m.width])
+	}
+	return line
+}
+
+func clip(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
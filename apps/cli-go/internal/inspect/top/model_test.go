@@ -0,0 +1,113 @@
+package top
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	snapshot Snapshot
+	signals  []pendingSignal
+}
+
+func (f *fakeSource) Snapshot(ctx context.Context) (Snapshot, error) {
+	return f.snapshot, nil
+}
+
+func (f *fakeSource) Signal(ctx context.Context, terminate bool, pid int) error {
+	f.signals = append(f.signals, pendingSignal{pid: pid, terminate: terminate})
+	return nil
+}
+
+func press(t *testing.T, m model, keys ...string) (model, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		var msg tea.KeyMsg
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+		updated, c := m.Update(msg)
+		m, cmd = updated.(model), c
+	}
+	return m, cmd
+}
+
+func TestTopModel(t *testing.T) {
+	source := &fakeSource{snapshot: Snapshot{
+		Activity: []Activity{
+			{Pid: 1, Usename: "postgres", State: "active", Duration: 1, Query: "select 1"},
+			{Pid: 2, Usename: "authenticator", State: "idle in transaction", Duration: 10, Query: "update todos"},
+			{Pid: 3, Usename: "postgres", State: "active", Duration: 5, Query: "vacuum todos"},
+		},
+		Timestamp: time.Now(),
+	}}
+
+	loaded := func(t *testing.T) model {
+		m := newModel(context.Background(), source, time.Second)
+		updated, cmd := m.Update(m.Init()())
+		require.NotNil(t, cmd)
+		return updated.(model)
+	}
+
+	t.Run("sorts by duration by default", func(t *testing.T) {
+		m := loaded(t)
+		rows := m.rows()
+		assert.Equal(t, []int{2, 3, 1}, []int{rows[0].Pid, rows[1].Pid, rows[2].Pid})
+		// Cycle to pid
+		m, _ = press(t, m, "s")
+		rows = m.rows()
+		assert.Equal(t, []int{1, 2, 3}, []int{rows[0].Pid, rows[1].Pid, rows[2].Pid})
+		assert.Contains(t, m.View(), "sort: pid")
+	})
+
+	t.Run("filters rows", func(t *testing.T) {
+		m := loaded(t)
+		m, _ = press(t, m, "/", "t", "o", "d", "o", "enter")
+		assert.False(t, m.filtering)
+		assert.Equal(t, "todo", m.filter)
+		rows := m.rows()
+		assert.Len(t, rows, 2)
+		assert.Contains(t, m.View(), "Active queries (2)")
+	})
+
+	t.Run("terminates after confirmation", func(t *testing.T) {
+		source.signals = nil
+		m := loaded(t)
+		m, _ = press(t, m, "down", "t")
+		require.NotNil(t, m.confirm)
+		assert.Contains(t, m.View(), "Terminate backend 3? [y/N]")
+		m, cmd := press(t, m, "y")
+		require.NotNil(t, cmd)
+		updated, _ := m.Update(cmd())
+		m = updated.(model)
+		assert.Equal(t, []pendingSignal{{pid: 3, terminate: true}}, source.signals)
+		assert.Equal(t, "Terminated backend 3", m.status)
+	})
+
+	t.Run("aborts without confirmation", func(t *testing.T) {
+		source.signals = nil
+		m := loaded(t)
+		m, _ = press(t, m, "c", "n")
+		assert.Nil(t, m.confirm)
+		assert.Equal(t, "Aborted", m.status)
+		assert.Empty(t, source.signals)
+	})
+
+	t.Run("ignores stale ticks", func(t *testing.T) {
+		m := loaded(t)
+		_, cmd := m.Update(tickMsg(m.tickID - 1))
+		assert.Nil(t, cmd)
+		_, cmd = m.Update(tickMsg(m.tickID))
+		assert.NotNil(t, cmd)
+	})
+}
@@ -0,0 +1,13 @@
+SELECT
+  pid,
+  COALESCE(usename, '') AS usename,
+  COALESCE(application_name, '') AS application_name,
+  COALESCE(state, '') AS state,
+  COALESCE(wait_event_type || ':' || wait_event, '') AS wait_event,
+  COALESCE(EXTRACT(epoch FROM now() - query_start), 0)::float8 AS duration,
+  COALESCE(query, '') AS query
+FROM pg_stat_activity
+WHERE pid <> pg_backend_pid()
+AND backend_type = 'client backend'
+AND state IS DISTINCT FROM 'idle'
+ORDER BY query_start
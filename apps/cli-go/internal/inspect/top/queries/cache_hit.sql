@@ -0,0 +1,3 @@
+SELECT
+  COALESCE(SUM(blks_hit)::float8 / nullif(SUM(blks_hit + blks_read), 0), 0)::float8 AS ratio
+FROM pg_stat_database
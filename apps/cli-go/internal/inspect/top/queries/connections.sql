@@ -0,0 +1,9 @@
+SELECT
+  COALESCE(usename, '') AS role_name,
+  count(*) FILTER (WHERE state = 'active') AS active,
+  count(*) FILTER (WHERE state LIKE 'idle in transaction%') AS idle_in_transaction,
+  count(*) AS total
+FROM pg_stat_activity
+WHERE backend_type = 'client backend'
+GROUP BY usename
+ORDER BY total DESC, role_name
@@ -0,0 +1,182 @@
+package top
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/inspect/blocking"
+	"github.com/supabase/cli/internal/inspect/locks"
+	"github.com/supabase/cli/internal/inspect/output"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgxv5"
+	"golang.org/x/term"
+)
+
+// Queries are nested one level deeper than other inspect commands so that they
+// are not picked up by inspect report.
+var (
+	//go:embed queries/activity.sql
+	ActivityQuery string
+	//go:embed queries/connections.sql
+	ConnectionsQuery string
+	//go:embed queries/cache_hit.sql
+	CacheHitQuery string
+
+	CancelQuery    = "SELECT pg_cancel_backend($1)"
+	TerminateQuery = "SELECT pg_terminate_backend($1)"
+)
+
+type Activity struct {
+	Pid              int
+	Usename          string
+	Application_name string
+	State            string
+	Wait_event       string
+	// Duration of the current query in seconds
+	Duration float64
+	Query    string
+}
+
+type Connections struct {
+	Role_name           string
+	Active              int64
+	Idle_in_transaction int64
+	Total               int64
+}
+
+type Snapshot struct {
+	Activity    []Activity
+	Locks       []locks.Result
+	Blocking    []blocking.Result
+	Connections []Connections
+	CacheHit    float64
+	Timestamp   time.Time
+}
+
+// client serialises access to the underlying connection because bubbletea
+// runs commands concurrently.
+type client struct {
+	mu   sync.Mutex
+	conn *pgx.Conn
+}
+
+func (c *client) Snapshot(ctx context.Context) (Snapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := Snapshot{Timestamp: time.Now()}
+	var err error
+	if result.Activity, err = queryRows[Activity](ctx, c.conn, ActivityQuery); err != nil {
+		return result, err
+	}
+	if result.Locks, err = queryRows[locks.Result](ctx, c.conn, locks.LocksQuery); err != nil {
+		return result, err
+	}
+	if result.Blocking, err = queryRows[blocking.Result](ctx, c.conn, blocking.BlockingQuery); err != nil {
+		return result, err
+	}
+	if result.Connections, err = queryRows[Connections](ctx, c.conn, ConnectionsQuery); err != nil {
+		return result, err
+	}
+	if err := c.conn.QueryRow(ctx, CacheHitQuery).Scan(&result.CacheHit); err != nil {
+		return result, errors.Errorf("failed to query cache hit ratio: %w", err)
+	}
+	return result, nil
+}
+
+// Signal cancels or terminates the backend with the given pid.
+func (c *client) Signal(ctx context.Context, terminate bool, pid int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sql := CancelQuery
+	if terminate {
+		sql = TerminateQuery
+	}
+	var ok bool
+	if err := c.conn.QueryRow(ctx, sql, pid).Scan(&ok); err != nil {
+		return errors.Errorf("failed to signal backend %d: %w", pid, err)
+	} else if !ok {
+		return errors.Errorf("backend %d was not signalled", pid)
+	}
+	return nil
+}
+
+func queryRows[T any](ctx context.Context, conn *pgx.Conn, sql string) ([]T, error) {
+	rows, err := conn.Query(ctx, sql)
+	if err != nil {
+		return nil, errors.Errorf("failed to query rows: %w", err)
+	}
+	return pgxv5.CollectRows[T](rows)
+}
+
+func Run(ctx context.Context, interval time.Duration, config pgconn.Config, options ...func(*pgx.ConnConfig)) error {
+	if interval < time.Second {
+		return errors.Errorf("invalid interval %s: must be at least 1s", interval)
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	c := &client{conn: conn}
+	// Print a single snapshot when output is piped to another program or
+	// consumed by scripts, in which case only active queries are written.
+	if !term.IsTerminal(int(os.Stdout.Fd())) || output.Format.Value != output.OutputTable || len(output.FailIf) > 0 {
+		snapshot, err := c.Snapshot(ctx)
+		if err != nil {
+			return err
+		}
+		return output.Write(ctx, snapshot.Activity, toMarkdown(snapshot))
+	}
+	m := newModel(ctx, c, interval)
+	return utils.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx)).Start()
+}
+
+func toMarkdown(s Snapshot) string {
+	var table strings.Builder
+	fmt.Fprintf(&table, "Cache hit ratio: `%s`\n\n", formatRatio(s.CacheHit))
+	table.WriteString("|role|active|idle in transaction|total|\n|-|-|-|-|\n")
+	for _, r := range s.Connections {
+		fmt.Fprintf(&table, "|`%s`|`%d`|`%d`|`%d`|\n", r.Role_name, r.Active, r.Idle_in_transaction, r.Total)
+	}
+	table.WriteString("\n|pid|role|state|wait event|duration|query|\n|-|-|-|-|-|-|\n")
+	for _, r := range s.Activity {
+		fmt.Fprintf(&table, "|`%d`|`%s`|`%s`|`%s`|`%s`|%s|\n", r.Pid, r.Usename, r.State, r.Wait_event, formatDuration(r.Duration), escapeQuery(r.Query))
+	}
+	table.WriteString("\n|blocked pid|blocking pid|blocked statement|blocked duration|\n|-|-|-|-|\n")
+	for _, r := range s.Blocking {
+		fmt.Fprintf(&table, "|`%d`|`%d`|%s|`%s`|\n", r.Blocked_pid, r.Blocking_pid, escapeQuery(r.Blocked_statement), r.Blocked_duration)
+	}
+	table.WriteString("\n|pid|relname|transaction id|granted|stmt|age|\n|-|-|-|-|-|-|\n")
+	for _, r := range s.Locks {
+		fmt.Fprintf(&table, "|`%d`|`%s`|`%s`|`%t`|%s|`%s`|\n", r.Pid, r.Relname, r.Transactionid, r.Granted, escapeQuery(r.Stmt), r.Age)
+	}
+	return table.String()
+}
+
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+func compactQuery(query string) string {
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(query, " "))
+}
+
+func escapeQuery(query string) string {
+	return strings.ReplaceAll(compactQuery(query), "|", `\|`)
+}
+
+func formatDuration(seconds float64) string {
+	return (time.Duration(seconds*float64(time.Second)) / time.Millisecond * time.Millisecond).String()
+}
+
+func formatRatio(ratio float64) string {
+	return fmt.Sprintf("%.2f%%", ratio*100)
+}
@@ -0,0 +1,101 @@
+package top
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/inspect/blocking"
+	"github.com/supabase/cli/internal/inspect/locks"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestTopCommand(t *testing.T) {
+	t.Run("prints snapshot without terminal", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(ActivityQuery).
+			Reply("SELECT 1", Activity{
+				Pid:              42,
+				Usename:          "postgres",
+				Application_name: "psql",
+				State:            "active",
+				Wait_event:       "Lock:relation",
+				Duration:         1.5,
+				Query:            "select 1",
+			}).
+			Query(locks.LocksQuery).
+			Reply("SELECT 0").
+			Query(blocking.BlockingQuery).
+			Reply("SELECT 0").
+			Query(ConnectionsQuery).
+			Reply("SELECT 1", Connections{
+				Role_name: "postgres",
+				Active:    1,
+				Total:     2,
+			}).
+			Query(CacheHitQuery).
+			Reply("SELECT 1", []any{0.99})
+		// Run test
+		err := Run(context.Background(), time.Second, dbConfig, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on invalid interval", func(t *testing.T) {
+		err := Run(context.Background(), time.Millisecond, dbConfig)
+		assert.ErrorContains(t, err, "invalid interval 1ms: must be at least 1s")
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(ActivityQuery).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for view pg_stat_activity")
+		// Run test
+		err := Run(context.Background(), time.Second, dbConfig, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for view pg_stat_activity")
+	})
+}
+
+func TestSignalBackend(t *testing.T) {
+	t.Run("terminates backend", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(TerminateQuery, 42).
+			Reply("SELECT 1", []any{true})
+		c := &client{conn: conn.MockClient(t)}
+		// Run test
+		err := c.Signal(context.Background(), true, 42)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error if not signalled", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(CancelQuery, 42).
+			Reply("SELECT 1", []any{false})
+		c := &client{conn: conn.MockClient(t)}
+		// Run test
+		err := c.Signal(context.Background(), false, 42)
+		// Check error
+		assert.ErrorContains(t, err, "backend 42 was not signalled")
+	})
+}
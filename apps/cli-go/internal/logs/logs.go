@@ -0,0 +1,274 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/container"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+type Entry struct {
+	Service string    `json:"service"`
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+type Options struct {
+	Follow bool
+	Since  string
+	Grep   string
+}
+
+func Run(ctx context.Context, services []string, opts Options, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	var pattern *regexp.Regexp
+	if len(opts.Grep) > 0 {
+		var err error
+		if pattern, err = regexp.Compile(opts.Grep); err != nil {
+			return errors.Errorf("failed to compile --grep pattern: %w", err)
+		}
+	}
+	names, err := resolveServices(ctx, services)
+	if err != nil {
+		return err
+	}
+	printer := newPrinter(os.Stdout, names, utils.OutputFormat.Value == utils.OutputJson)
+	// Entries are sorted by time unless following, where they are printed on arrival
+	var entries []Entry
+	var mu sync.Mutex
+	handle := func(e Entry) {
+		if pattern != nil && !pattern.MatchString(e.Message) {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if opts.Follow {
+			printer.print(e)
+		} else {
+			entries = append(entries, e)
+		}
+	}
+	errs := utils.WaitAll(names, func(name string) error {
+		return streamLogs(ctx, name, opts, handle)
+	})
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	for _, e := range entries {
+		printer.print(e)
+	}
+	return errors.Join(errs...)
+}
+
+// resolveServices returns the names of running services matching the given
+// names, or all running services if none are given.
+func resolveServices(ctx context.Context, services []string) ([]string, error) {
+	resp, err := utils.Docker.ContainerList(ctx, container.ListOptions{
+		Filters: utils.CliProjectFilter(utils.Config.ProjectId),
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list running containers: %w", err)
+	}
+	var running []string
+	for _, c := range resp {
+		if len(c.Names) > 0 {
+			running = append(running, utils.ServiceName(c.Names[0]))
+		}
+	}
+	if len(running) == 0 {
+		return nil, errors.New(utils.ErrNotRunning)
+	}
+	sort.Strings(running)
+	if len(services) == 0 {
+		return running, nil
+	}
+	for _, name := range services {
+		if !slices.Contains(running, name) {
+			return nil, errors.Errorf("service is not running: %s\nRunning services are: %s", name, strings.Join(running, ", "))
+		}
+	}
+	return utils.RemoveDuplicates(services), nil
+}
+
+func streamLogs(ctx context.Context, name string, opts Options, handle func(Entry)) error {
+	r, w := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 1024*1024)
+		for scanner.Scan() {
+			if line := scanner.Text(); len(strings.TrimSpace(line)) > 0 {
+				handle(ParseLine(name, line))
+			}
+		}
+		// Drain the pipe so that the writer never blocks
+		_, _ = io.Copy(io.Discard, r)
+	}()
+	err := utils.DockerStreamLogs(ctx, utils.GetId(name), w, w, func(lo *container.LogsOptions) {
+		lo.Follow = opts.Follow
+		lo.Since = opts.Since
+		lo.Timestamps = true
+	})
+	w.Close()
+	<-done
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// ParseLine converts a timestamped docker log line to a common format.
+func ParseLine(service, line string) Entry {
+	entry := Entry{Service: service, Level: "info", Message: line}
+	if ts, msg, ok := strings.Cut(line, " "); ok {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			entry.Time = t.UTC()
+			entry.Message = msg
+		}
+	}
+	if !parseJson(&entry) {
+		parseText(&entry)
+	}
+	return entry
+}
+
+var (
+	levelKeys   = []string{"level", "lvl", "severity", "log_level"}
+	messageKeys = []string{"msg", "message", "event_message", "error"}
+)
+
+// parseJson handles structured logs from services such as auth and storage.
+func parseJson(entry *Entry) bool {
+	if !strings.HasPrefix(entry.Message, "{") {
+		return false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(entry.Message), &fields); err != nil {
+		return false
+	}
+levels:
+	for _, k := range levelKeys {
+		switch v := fields[k].(type) {
+		case string:
+			entry.Level = normalizeLevel(v)
+			break levels
+		case float64:
+			entry.Level = pinoLevel(v)
+			break levels
+		}
+	}
+	for _, k := range messageKeys {
+		if v, ok := fields[k].(string); ok && len(v) > 0 {
+			entry.Message = v
+			break
+		}
+	}
+	return true
+}
+
+var (
+	// Postgres: 2024-01-01 00:00:00.000 UTC [1] LOG:  message
+	postgresPattern = regexp.MustCompile(`^.*?\b(DEBUG\d?|LOG|INFO|NOTICE|WARNING|ERROR|FATAL|PANIC):\s+(.*)$`)
+	// Elixir: 12:00:00.000 [info] message
+	elixirPattern = regexp.MustCompile(`^.*?\[(debug|info|notice|warning|warn|error|critical|alert|emergency)\]\s*(.*)$`)
+)
+
+func parseText(entry *Entry) {
+	for _, p := range []*regexp.Regexp{postgresPattern, elixirPattern} {
+		if m := p.FindStringSubmatch(entry.Message); len(m) > 2 {
+			entry.Level = normalizeLevel(m[1])
+			entry.Message = m[2]
+			return
+		}
+	}
+}
+
+func normalizeLevel(level string) string {
+	switch strings.ToLower(level) {
+	case "trace", "debug", "debug1", "debug2", "debug3", "debug4", "debug5":
+		return "debug"
+	case "warn", "warning":
+		return "warn"
+	case "error", "err":
+		return "error"
+	case "fatal", "panic", "critical", "alert", "emergency":
+		return "fatal"
+	}
+	return "info"
+}
+
+// Ref: https://getpino.io/#/docs/api?id=loggerlevels-object
+func pinoLevel(level float64) string {
+	switch {
+	case level < 30:
+		return "debug"
+	case level < 40:
+		return "info"
+	case level < 50:
+		return "warn"
+	case level < 60:
+		return "error"
+	}
+	return "fatal"
+}
+
+var palette = []string{"14", "10", "11", "13", "12", "6", "2", "3", "5", "4"}
+
+type printer struct {
+	w       io.Writer
+	json    *json.Encoder
+	prefix  map[string]string
+	padding int
+}
+
+func newPrinter(w io.Writer, services []string, asJson bool) *printer {
+	p := printer{w: w, prefix: make(map[string]string, len(services))}
+	if asJson {
+		p.json = json.NewEncoder(w)
+		return &p
+	}
+	for _, name := range services {
+		p.padding = max(p.padding, len(name))
+	}
+	for i, name := range services {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(palette[i%len(palette)]))
+		p.prefix[name] = style.Render(fmt.Sprintf("%-*s |", p.padding, name))
+	}
+	return &p
+}
+
+func (p *printer) print(e Entry) {
+	if p.json != nil {
+		if err := p.json.Encode(e); err != nil {
+			fmt.Fprintln(utils.GetDebugLogger(), err)
+		}
+		return
+	}
+	level := strings.ToUpper(e.Level)
+	switch e.Level {
+	case "warn":
+		level = utils.Yellow(level)
+	case "error", "fatal":
+		level = utils.Red(level)
+	}
+	fmt.Fprintf(p.w, "%s %s %s %s\n", p.prefix[e.Service], e.Time.Local().Format(time.TimeOnly), level, e.Message)
+}
@@ -0,0 +1,119 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestParseLine(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 13, 23, 37, 0, time.UTC)
+
+	cases := []struct {
+		name  string
+		line  string
+		level string
+		msg   string
+	}{
+		{"auth json", `2024-01-02T13:23:37Z {"level":"warning","msg":"request failed","time":"2024-01-02T13:23:37Z"}`, "warn", "request failed"},
+		{"storage pino", `2024-01-02T13:23:37Z {"level":50,"time":1704201817000,"msg":"bucket not found"}`, "error", "bucket not found"},
+		{"postgres text", `2024-01-02T13:23:37Z 2024-01-02 13:23:37.000 UTC [42] FATAL:  password authentication failed`, "fatal", "password authentication failed"},
+		{"elixir text", `2024-01-02T13:23:37Z 13:23:37.000 [error] tenant not found`, "error", "tenant not found"},
+		{"plain text", `2024-01-02T13:23:37Z Listening on port 3000`, "info", "Listening on port 3000"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			entry := ParseLine("auth", c.line)
+			assert.Equal(t, Entry{Service: "auth", Time: ts, Level: c.level, Message: c.msg}, entry)
+		})
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	entry := Entry{Service: "rest", Time: time.Date(2024, 1, 2, 13, 23, 37, 0, time.UTC), Level: "info", Message: "ready"}
+
+	t.Run("prints json lines", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := newPrinter(&buf, []string{"auth", "rest"}, true)
+		// Run test
+		p.print(entry)
+		// Check output
+		assert.JSONEq(t, `{"service":"rest","time":"2024-01-02T13:23:37Z","level":"info","message":"ready"}`, buf.String())
+	})
+
+	t.Run("prints service prefix", func(t *testing.T) {
+		var buf bytes.Buffer
+		p := newPrinter(&buf, []string{"storage", "rest"}, false)
+		// Run test
+		p.print(entry)
+		// Check output
+		assert.Contains(t, buf.String(), "rest    |")
+		assert.True(t, strings.HasSuffix(buf.String(), "INFO ready\n"))
+	})
+}
+
+func TestLogsCommand(t *testing.T) {
+	t.Run("filters logs of selected service", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+			Reply(http.StatusOK).
+			JSON([]container.Summary{
+				{Names: []string{"/supabase_auth_test"}},
+				{Names: []string{"/supabase_rest_test"}},
+			})
+		logs := strings.NewReader(`2024-01-02T13:23:37Z {"level":"info","msg":"started"}
+2024-01-02T13:23:38Z {"level":"error","msg":"invalid jwt"}
+`)
+		require.NoError(t, apitest.MockDockerLogsStream(utils.Docker, "supabase_auth_test", 0, logs))
+		// Run test
+		err := Run(context.Background(), []string{"auth"}, Options{Grep: "jwt"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on unknown service", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+			Reply(http.StatusOK).
+			JSON([]container.Summary{{Names: []string{"/supabase_auth_test"}}})
+		// Run test
+		err := Run(context.Background(), []string{"gotrue"}, Options{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "service is not running: gotrue")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid pattern", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		err := Run(context.Background(), nil, Options{Grep: "("}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to compile --grep pattern:")
+	})
+}
@@ -0,0 +1,44 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/mail"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+func Run(ctx context.Context, filter mail.Filter, limit uint, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	client, err := mail.NewMailClient()
+	if err != nil {
+		return err
+	}
+	messages, err := mail.ListMessages(ctx, client, filter, limit)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, messages)
+	}
+	var table strings.Builder
+	table.WriteString(`|ID|FROM|TO|SUBJECT|RECEIVED AT (UTC)|
+|-|-|-|-|-|
+`)
+	for _, m := range messages {
+		fmt.Fprintf(&table, "|`%s`|`%s`|`%s`|`%s`|`%s`|\n",
+			m.ID,
+			m.From.Address,
+			mail.FormatAddresses(m.To),
+			strings.ReplaceAll(m.Subject, "|", "\\|"),
+			utils.FormatTime(m.Created),
+		)
+	}
+	return utils.RenderTable(table.String())
+}
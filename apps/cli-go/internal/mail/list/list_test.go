@@ -0,0 +1,55 @@
+package list
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/mail"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestListMessages(t *testing.T) {
+	t.Run("lists all emails", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1:54324").
+			Get("/api/v1/messages").
+			MatchParam("limit", "50").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"messages": []map[string]any{{
+				"ID":      "abc",
+				"From":    map[string]string{"Address": "admin@email.com"},
+				"To":      []map[string]string{{"Address": "user@example.com"}},
+				"Subject": "Confirm Your Signup",
+			}}})
+		// Run test
+		err := Run(context.Background(), mail.Filter{}, 50, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on disabled mail server", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`
+project_id = "test"
+[inbucket]
+enabled = false
+`), 0644))
+		// Run test
+		err := Run(context.Background(), mail.Filter{}, 50, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Mail server is disabled.")
+	})
+}
@@ -0,0 +1,209 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/status"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+type Address struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+type Summary struct {
+	ID      string    `json:"id"`
+	From    Address   `json:"from"`
+	To      []Address `json:"to"`
+	Subject string    `json:"subject"`
+	Created time.Time `json:"created"`
+	Snippet string    `json:"snippet"`
+}
+
+type Message struct {
+	ID         string    `json:"id"`
+	From       Address   `json:"from"`
+	To         []Address `json:"to"`
+	Subject    string    `json:"subject"`
+	Date       time.Time `json:"date"`
+	Text       string    `json:"text"`
+	HTML       string    `json:"html"`
+	MagicLinks []string  `json:"magic_links"`
+	OtpCodes   []string  `json:"otp_codes"`
+}
+
+type Filter struct {
+	To      string
+	Subject string
+}
+
+// Query builds a Mailpit search query, ie. to:"user@example.com" subject:"Confirm"
+func (f Filter) Query() string {
+	var terms []string
+	if len(f.To) > 0 {
+		terms = append(terms, "to:"+strconv.Quote(f.To))
+	}
+	if len(f.Subject) > 0 {
+		terms = append(terms, "subject:"+strconv.Quote(f.Subject))
+	}
+	return strings.Join(terms, " ")
+}
+
+func GetMailUrl() string {
+	// Kong terminates TLS for the mail UI when using locally trusted certs
+	scheme := "http"
+	if utils.LocalCertsEnabled {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, utils.Config.Hostname, utils.Config.Inbucket.Port)
+}
+
+func NewMailClient() (*fetcher.Fetcher, error) {
+	if !utils.Config.Inbucket.Enabled {
+		return nil, errors.New("Mail server is disabled. Enable it in your supabase/config.toml under [inbucket].")
+	}
+	return fetcher.NewFetcher(
+		GetMailUrl(),
+		fetcher.WithHTTPClient(status.NewKongClient()),
+		fetcher.WithExpectedStatus(http.StatusOK),
+	), nil
+}
+
+type messagesResponse struct {
+	Total    int       `json:"total"`
+	Messages []Summary `json:"messages"`
+}
+
+// ListMessages returns captured emails matching the filter, newest first.
+func ListMessages(ctx context.Context, client *fetcher.Fetcher, filter Filter, limit uint) ([]Summary, error) {
+	params := url.Values{"limit": {strconv.FormatUint(uint64(limit), 10)}}
+	endpoint := "/api/v1/messages"
+	if q := filter.Query(); len(q) > 0 {
+		params.Set("query", q)
+		endpoint = "/api/v1/search"
+	}
+	resp, err := client.Send(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		utils.CmdSuggestion = suggestStart()
+		return nil, errors.Errorf("failed to list emails: %w", err)
+	}
+	body, err := fetcher.ParseJSON[messagesResponse](resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return body.Messages, nil
+}
+
+// GetMessage returns a captured email by ID, or the most recent email if ID is "latest".
+func GetMessage(ctx context.Context, client *fetcher.Fetcher, id string) (Message, error) {
+	resp, err := client.Send(ctx, http.MethodGet, "/api/v1/message/"+url.PathEscape(id), nil)
+	if err != nil {
+		utils.CmdSuggestion = suggestStart()
+		return Message{}, errors.Errorf("failed to get email: %w", err)
+	}
+	msg, err := fetcher.ParseJSON[Message](resp.Body)
+	if err != nil {
+		return msg, err
+	}
+	body := msg.Text
+	if len(body) == 0 {
+		body = stripTags(msg.HTML)
+	}
+	msg.MagicLinks = ExtractLinks(msg.Text + "\n" + html.UnescapeString(msg.HTML))
+	msg.OtpCodes = ExtractOtpCodes(body, utils.Config.Auth.Email.OtpLength)
+	return msg, nil
+}
+
+func suggestStart() string {
+	return fmt.Sprintf("Make sure your local development setup is running: %s", utils.Aqua("supabase start"))
+}
+
+var (
+	linkPattern = regexp.MustCompile(`https?://[^\s"'<>()\[\]]+`)
+	tagPattern  = regexp.MustCompile(`(?is)<(style|script)[^>]*>.*?</(style|script)>|<[^>]+>`)
+)
+
+// ExtractLinks returns unique auth links, ie. those containing a verify path or token param.
+func ExtractLinks(body string) []string {
+	var links []string
+	for _, link := range linkPattern.FindAllString(body, -1) {
+		u, err := url.Parse(link)
+		if err != nil {
+			continue
+		}
+		q := u.Query()
+		if !strings.Contains(u.Path, "/verify") && !q.Has("token") && !q.Has("token_hash") {
+			continue
+		}
+		if !slices.Contains(links, link) {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// ExtractOtpCodes returns unique numeric codes of the configured OTP length.
+func ExtractOtpCodes(body string, length uint) []string {
+	if length == 0 {
+		length = 6
+	}
+	pattern := regexp.MustCompile(fmt.Sprintf(`\b\d{%d}\b`, length))
+	var codes []string
+	for _, code := range pattern.FindAllString(body, -1) {
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+func stripTags(body string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(body, " "))
+}
+
+// FormatAddresses joins the recipient addresses for display.
+func FormatAddresses(addrs []Address) string {
+	result := make([]string, len(addrs))
+	for i, a := range addrs {
+		result[i] = a.Address
+	}
+	return strings.Join(result, ", ")
+}
+
+// RenderMessage writes the email headers, extracted auth artifacts, and text body.
+func RenderMessage(msg Message) error {
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, msg)
+	}
+	fmt.Println("ID:      " + msg.ID)
+	fmt.Println("From:    " + msg.From.Address)
+	fmt.Println("To:      " + FormatAddresses(msg.To))
+	fmt.Println("Subject: " + msg.Subject)
+	fmt.Println("Date:    " + utils.FormatTime(msg.Date))
+	for _, link := range msg.MagicLinks {
+		fmt.Println("Link:    " + link)
+	}
+	for _, code := range msg.OtpCodes {
+		fmt.Println("OTP:     " + code)
+	}
+	body := msg.Text
+	if len(body) == 0 {
+		body = strings.TrimSpace(stripTags(msg.HTML))
+	}
+	fmt.Println()
+	fmt.Println(body)
+	return nil
+}
@@ -0,0 +1,83 @@
+package mail
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+func TestFilterQuery(t *testing.T) {
+	assert.Empty(t, Filter{}.Query())
+	assert.Equal(t, `to:"user@example.com" subject:"Confirm \"Signup\""`, Filter{
+		To:      "user@example.com",
+		Subject: `Confirm "Signup"`,
+	}.Query())
+}
+
+func TestExtractLinks(t *testing.T) {
+	body := `<a href="http://127.0.0.1:54321/auth/v1/verify?token=abc&type=signup&redirect_to=http://localhost:3000">Confirm</a>
+	<a href="http://localhost:3000/reset?token_hash=xyz">Reset</a>
+	<a href="https://supabase.com">Home</a>`
+	// Run test
+	links := ExtractLinks(body)
+	// Check output
+	assert.Equal(t, []string{
+		"http://127.0.0.1:54321/auth/v1/verify?token=abc&type=signup&redirect_to=http://localhost:3000",
+		"http://localhost:3000/reset?token_hash=xyz",
+	}, links)
+}
+
+func TestExtractOtpCodes(t *testing.T) {
+	body := "Your code is 123456. Alternatively enter 123456 or 98765432."
+	assert.Equal(t, []string{"123456"}, ExtractOtpCodes(body, 0))
+	assert.Equal(t, []string{"98765432"}, ExtractOtpCodes(body, 8))
+}
+
+func TestGetMessage(t *testing.T) {
+	mailUrl := GetMailUrl()
+	client := fetcher.NewFetcher(mailUrl, fetcher.WithExpectedStatus(http.StatusOK))
+
+	t.Run("extracts auth artifacts from html", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mailUrl).
+			Get("/api/v1/message/latest").
+			Reply(http.StatusOK).
+			JSON(map[string]any{
+				"ID":      "abc",
+				"Subject": "Confirm Your Signup",
+				"To":      []map[string]string{{"Address": "user@example.com"}},
+				"HTML": `<style>p { color: #000000; }</style>
+<p>Enter the code: 654321</p>
+<a href="http://127.0.0.1:54321/auth/v1/verify?token=pkce_1&amp;type=signup">Confirm</a>`,
+			})
+		// Run test
+		msg, err := GetMessage(context.Background(), client, "latest")
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Equal(t, "user@example.com", FormatAddresses(msg.To))
+		assert.Equal(t, []string{"654321"}, msg.OtpCodes)
+		assert.Equal(t, []string{"http://127.0.0.1:54321/auth/v1/verify?token=pkce_1&type=signup"}, msg.MagicLinks)
+	})
+
+	t.Run("throws error on missing message", func(t *testing.T) {
+		utils.CmdSuggestion = ""
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mailUrl).
+			Get("/api/v1/message/missing").
+			Reply(http.StatusNotFound)
+		// Run test
+		_, err := GetMessage(context.Background(), client, "missing")
+		// Check error
+		assert.ErrorContains(t, err, "failed to get email: Error status 404")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
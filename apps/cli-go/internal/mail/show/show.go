@@ -0,0 +1,24 @@
+package show
+
+import (
+	"context"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/mail"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+func Run(ctx context.Context, id string, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	client, err := mail.NewMailClient()
+	if err != nil {
+		return err
+	}
+	msg, err := mail.GetMessage(ctx, client, id)
+	if err != nil {
+		return err
+	}
+	return mail.RenderMessage(msg)
+}
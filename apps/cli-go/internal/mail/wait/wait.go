@@ -0,0 +1,57 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/mail"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+var errNoMatch = errors.New("no matching email")
+
+func Run(ctx context.Context, filter mail.Filter, since, timeout time.Duration, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	client, err := mail.NewMailClient()
+	if err != nil {
+		return err
+	}
+	// Emails captured before waiting started are stale unless requested
+	after := time.Now().Add(-since)
+	fmt.Fprintln(os.Stderr, "Waiting for email...")
+	msg, err := WaitForMessage(ctx, client, filter, after, timeout)
+	if err != nil {
+		return err
+	}
+	return mail.RenderMessage(msg)
+}
+
+// WaitForMessage polls the mail server until an email matching the filter is
+// captured after the given time, returning the most recent match.
+func WaitForMessage(ctx context.Context, client *fetcher.Fetcher, filter mail.Filter, after time.Time, timeout time.Duration) (mail.Message, error) {
+	poll := func() (string, error) {
+		messages, err := mail.ListMessages(ctx, client, filter, 1)
+		if err != nil {
+			return "", backoff.Permanent(err)
+		} else if len(messages) == 0 || messages[0].Created.Before(after) {
+			return "", errNoMatch
+		}
+		return messages[0].ID, nil
+	}
+	id, err := backoff.RetryWithData(poll, start.NewBackoffPolicy(ctx, timeout))
+	if errors.Is(err, errNoMatch) {
+		return mail.Message{}, errors.Errorf("timed out after %s waiting for a matching email", timeout)
+	} else if err != nil {
+		return mail.Message{}, err
+	}
+	return mail.GetMessage(ctx, client, id)
+}
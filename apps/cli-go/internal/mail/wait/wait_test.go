@@ -0,0 +1,97 @@
+package wait
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/mail"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+func TestWaitForMessage(t *testing.T) {
+	mailUrl := mail.GetMailUrl()
+	client := fetcher.NewFetcher(mailUrl, fetcher.WithExpectedStatus(http.StatusOK))
+	filter := mail.Filter{To: "user@example.com"}
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("polls until email is captured", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mailUrl).
+			Get("/api/v1/search").
+			MatchParam("query", `to:"user@example.com"`).
+			Reply(http.StatusOK).
+			JSON(map[string]any{"messages": []any{}})
+		gock.New(mailUrl).
+			Get("/api/v1/search").
+			MatchParam("query", `to:"user@example.com"`).
+			Reply(http.StatusOK).
+			JSON(map[string]any{"messages": []map[string]string{{"ID": "abc", "Created": "2024-01-01T00:00:01Z"}}})
+		gock.New(mailUrl).
+			Get("/api/v1/message/abc").
+			Reply(http.StatusOK).
+			JSON(map[string]string{"ID": "abc", "Text": "Your code is 123456"})
+		// Run test
+		msg, err := WaitForMessage(context.Background(), client, filter, after, 5*time.Second)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Equal(t, []string{"123456"}, msg.OtpCodes)
+	})
+
+	t.Run("ignores emails captured before waiting", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mailUrl).
+			Get("/api/v1/search").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"messages": []map[string]string{{"ID": "old", "Created": "2023-12-31T23:59:59Z"}}})
+		gock.New(mailUrl).
+			Get("/api/v1/search").
+			Reply(http.StatusOK).
+			JSON(map[string]any{"messages": []map[string]string{{"ID": "new", "Created": "2024-01-01T00:00:01Z"}}})
+		gock.New(mailUrl).
+			Get("/api/v1/message/new").
+			Reply(http.StatusOK).
+			JSON(map[string]string{"ID": "new"})
+		// Run test
+		msg, err := WaitForMessage(context.Background(), client, filter, after, 5*time.Second)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Equal(t, "new", msg.ID)
+	})
+
+	t.Run("throws error on timeout", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mailUrl).
+			Get("/api/v1/search").
+			Times(2).
+			Reply(http.StatusOK).
+			JSON(map[string]any{"messages": []any{}})
+		// Run test
+		_, err := WaitForMessage(context.Background(), client, filter, after, time.Second)
+		// Check error
+		assert.ErrorContains(t, err, "timed out after 1s waiting for a matching email")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on server error", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mailUrl).
+			Get("/api/v1/search").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := WaitForMessage(context.Background(), client, filter, after, time.Minute)
+		// Check error
+		assert.ErrorContains(t, err, "failed to list emails: Error status 503")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
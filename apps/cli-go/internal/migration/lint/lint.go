@@ -0,0 +1,527 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	mg "github.com/multigres/multigres/go/parser"
+	"github.com/multigres/multigres/go/parser/ast"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/sarif"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/parser"
+)
+
+const (
+	LevelWarning = "warning"
+	LevelError   = "error"
+
+	OutputText = "text"
+)
+
+var (
+	AllowedLevels = []string{
+		LevelWarning,
+		LevelError,
+	}
+
+	AllowedOutputs = []string{
+		OutputText,
+		utils.OutputJson,
+		utils.OutputSarif,
+	}
+)
+
+type Rule struct {
+	ID          string
+	Level       string
+	Description string
+}
+
+var (
+	RuleMissingIfNotExists = Rule{
+		ID:          "missing_if_not_exists",
+		Level:       LevelWarning,
+		Description: "Files that create objects idempotently should use IF NOT EXISTS or OR REPLACE on every create statement.",
+	}
+	RuleSearchPath = Rule{
+		ID:          "security_definer_search_path",
+		Level:       LevelWarning,
+		Description: "Security definer functions should set search_path to prevent privilege escalation through shadowed objects.",
+	}
+	RuleRlsDisabled = Rule{
+		ID:          "rls_disabled_in_public",
+		Level:       LevelError,
+		Description: "Tables in the public schema are exposed through the Data API and should enable row level security.",
+	}
+	RuleGrantAnon = Rule{
+		ID:          "grant_to_anon",
+		Level:       LevelWarning,
+		Description: "Privileges granted to the anon role are available to every unauthenticated request.",
+	}
+	RuleMixedStatements = Rule{
+		ID:          "mixed_ddl_dml",
+		Level:       LevelWarning,
+		Description: "Schema changes and data changes should be kept in separate migration files.",
+	}
+	RuleUnparsable = Rule{
+		ID:          "unparsable_statement",
+		Level:       LevelWarning,
+		Description: "Statements that cannot be parsed are not checked by any other rule.",
+	}
+	Rules = []Rule{
+		RuleMissingIfNotExists,
+		RuleSearchPath,
+		RuleRlsDisabled,
+		RuleGrantAnon,
+		RuleMixedStatements,
+		RuleUnparsable,
+	}
+)
+
+type Finding struct {
+	Rule    string `json:"rule"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+}
+
+func newFinding(rule Rule, stmt statement, format string, args ...any) Finding {
+	return Finding{
+		Rule:    rule.ID,
+		Level:   rule.Level,
+		Message: fmt.Sprintf(format, args...),
+		File:    filepath.ToSlash(stmt.file),
+		Line:    stmt.line,
+	}
+}
+
+func Run(ctx context.Context, paths []string, output string, failOn string, fsys afero.Fs) error {
+	if len(paths) == 0 {
+		var err error
+		if paths, err = listDefaultPaths(fsys); err != nil {
+			return err
+		}
+	}
+	findings, err := LintFiles(paths, fsys)
+	if err != nil {
+		return err
+	}
+	if err := printFindings(findings, output, os.Stdout); err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		fmt.Fprintln(os.Stderr, "No issues found in", len(paths), "files")
+	}
+	failOnLevel := toEnum(failOn)
+	if failOnLevel < 0 {
+		return nil
+	}
+	for _, f := range findings {
+		if toEnum(f.Level) >= failOnLevel {
+			return errors.Errorf("fail-on is set to %s, non-zero exit", failOn)
+		}
+	}
+	return nil
+}
+
+func toEnum(level string) int {
+	for i, curr := range AllowedLevels {
+		if level == curr {
+			return i
+		}
+	}
+	return -1
+}
+
+func listDefaultPaths(fsys afero.Fs) ([]string, error) {
+	paths, err := migration.ListLocalMigrations(utils.MigrationsDir, afero.NewIOFS(fsys))
+	if err != nil {
+		return nil, err
+	}
+	if exists, err := afero.DirExists(fsys, utils.SchemasDir); err != nil {
+		return nil, errors.Errorf("failed to check schemas: %w", err)
+	} else if !exists {
+		return paths, nil
+	}
+	var declared []string
+	if err := afero.Walk(fsys, utils.SchemasDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() && filepath.Ext(info.Name()) == ".sql" {
+			declared = append(declared, path)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Errorf("failed to walk dir: %w", err)
+	}
+	sort.Strings(declared)
+	return append(declared, paths...), nil
+}
+
+type statement struct {
+	file string
+	line int
+	node ast.Node
+}
+
+// LintFiles checks SQL files in the given order. Rules that span multiple
+// files, such as enabling RLS after creating a table, consider all files.
+func LintFiles(paths []string, fsys afero.Fs) ([]Finding, error) {
+	var findings []Finding
+	var all []statement
+	order := make(map[string]int, len(paths))
+	for i, fp := range paths {
+		order[filepath.ToSlash(fp)] = i
+		stats, skipped, err := parseFile(fp, fsys)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, skipped...)
+		findings = append(findings, lintFile(stats)...)
+		all = append(all, stats...)
+	}
+	findings = append(findings, checkRowLevelSecurity(all)...)
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File == findings[j].File {
+			return findings[i].Line < findings[j].Line
+		}
+		return order[findings[i].File] < order[findings[j].File]
+	})
+	return findings, nil
+}
+
+// parseFile returns the parsed statements of a file, and a finding for each
+// statement that could not be parsed.
+func parseFile(path string, fsys afero.Fs) ([]statement, []Finding, error) {
+	contents, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to read file: %w", err)
+	}
+	tokens, err := parser.Split(bytes.NewReader(contents))
+	if err != nil {
+		return nil, nil, err
+	}
+	var result []statement
+	var skipped []Finding
+	// Tokens are split as is, so line numbers can be counted incrementally
+	line := 1
+	for _, token := range tokens {
+		offset := strings.Count(token[:len(token)-len(trimComments(token))], "\n")
+		start := line + offset
+		line += strings.Count(token, "\n")
+		sql := strings.TrimSpace(token)
+		if len(trimComments(sql)) == 0 {
+			continue
+		}
+		parsed, err := mg.ParseSQL(sql)
+		if err != nil {
+			stmt := statement{file: path, line: start}
+			skipped = append(skipped, newFinding(RuleUnparsable, stmt, "Statement could not be parsed: %v", err))
+			continue
+		}
+		for _, n := range parsed {
+			result = append(result, statement{file: path, line: start, node: n})
+		}
+	}
+	return result, skipped, nil
+}
+
+// Removes leading white spaces and comments from a statement.
+func trimComments(sql string) string {
+	for {
+		sql = strings.TrimLeft(sql, " \t\r\n")
+		if strings.HasPrefix(sql, "--") {
+			if _, after, found := strings.Cut(sql, "\n"); found {
+				sql = after
+				continue
+			}
+			return ""
+		}
+		if strings.HasPrefix(sql, "/*") {
+			if _, after, found := strings.Cut(sql, "*/"); found {
+				sql = after
+				continue
+			}
+			return ""
+		}
+		return sql
+	}
+}
+
+func lintFile(stats []statement) []Finding {
+	var findings []Finding
+	idempotent := false
+	for _, s := range stats {
+		if ok, found := isIdempotent(s.node); found && ok {
+			idempotent = true
+			break
+		}
+	}
+	var firstDDL, firstDML *statement
+	for i, s := range stats {
+		if idempotent {
+			if ok, found := isIdempotent(s.node); found && !ok {
+				findings = append(findings, newFinding(RuleMissingIfNotExists, s, "%s is missing IF NOT EXISTS or OR REPLACE", describe(s.node)))
+			}
+		}
+		switch v := s.node.(type) {
+		case *ast.CreateFunctionStmt:
+			if isSecurityDefiner(v) && !setsSearchPath(v) {
+				findings = append(findings, newFinding(RuleSearchPath, s, "Function %s is SECURITY DEFINER without SET search_path", strings.Join(toQualifiedName(v.FuncName), ".")))
+			}
+		case *ast.GrantStmt:
+			if v.IsGrant && grantsTo(v, "anon") {
+				findings = append(findings, newFinding(RuleGrantAnon, s, "Privileges are granted to the anon role"))
+			}
+		}
+		if isDML(s.node) {
+			if firstDML == nil {
+				firstDML = &stats[i]
+			}
+		} else if firstDDL == nil && isDDL(s.node) {
+			firstDDL = &stats[i]
+		}
+	}
+	if firstDDL != nil && firstDML != nil {
+		findings = append(findings, newFinding(RuleMixedStatements, *firstDML, "File mixes schema changes with data changes starting at line %d", firstDML.line))
+	}
+	return findings
+}
+
+// Returns whether a create statement is idempotent, and whether it supports
+// being declared idempotently at all.
+func isIdempotent(n ast.Node) (ok bool, found bool) {
+	switch v := n.(type) {
+	case *ast.CreateStmt:
+		return v.IfNotExists, true
+	case *ast.CreateSchemaStmt:
+		return v.IfNotExists, true
+	case *ast.CreateExtensionStmt:
+		return v.IfNotExists, true
+	case *ast.CreateSeqStmt:
+		return v.IfNotExists, true
+	case *ast.IndexStmt:
+		return v.IfNotExists, true
+	case *ast.CreateTableAsStmt:
+		return v.IfNotExists, true
+	case *ast.CreateFunctionStmt:
+		return v.Replace, true
+	case *ast.ViewStmt:
+		return v.Replace, true
+	}
+	return false, false
+}
+
+func describe(n ast.Node) string {
+	switch v := n.(type) {
+	case *ast.CreateStmt:
+		return "Table " + toRelationName(v.Relation)
+	case *ast.CreateSchemaStmt:
+		return "Schema " + v.Schemaname
+	case *ast.CreateExtensionStmt:
+		return "Extension " + v.Extname
+	case *ast.CreateSeqStmt:
+		return "Sequence " + toRelationName(v.Sequence)
+	case *ast.IndexStmt:
+		return "Index " + v.Idxname
+	case *ast.CreateTableAsStmt:
+		if v.Into != nil {
+			return "Relation " + toRelationName(v.Into.Rel)
+		}
+	case *ast.CreateFunctionStmt:
+		return "Function " + strings.Join(toQualifiedName(v.FuncName), ".")
+	case *ast.ViewStmt:
+		return "View " + toRelationName(v.View)
+	}
+	return fmt.Sprintf("%T", n)
+}
+
+func isSecurityDefiner(f *ast.CreateFunctionStmt) bool {
+	for _, o := range listItems(f.Options) {
+		if d, ok := o.(*ast.DefElem); ok && d.Defname == "security" {
+			if b, ok := d.Arg.(*ast.Boolean); ok {
+				return b.BoolVal
+			}
+		}
+	}
+	return false
+}
+
+func setsSearchPath(f *ast.CreateFunctionStmt) bool {
+	for _, o := range listItems(f.Options) {
+		if d, ok := o.(*ast.DefElem); ok && d.Defname == "set" {
+			if v, ok := d.Arg.(*ast.VariableSetStmt); ok && strings.EqualFold(v.Name, "search_path") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func grantsTo(g *ast.GrantStmt, role string) bool {
+	for _, n := range listItems(g.Grantees) {
+		if r, ok := n.(*ast.RoleSpec); ok && r.Rolename == role {
+			return true
+		}
+	}
+	return false
+}
+
+func isDML(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt, *ast.CopyStmt, *ast.MergeStmt:
+		return true
+	}
+	return false
+}
+
+func isDDL(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.SelectStmt, *ast.VariableSetStmt, *ast.TransactionStmt, *ast.CallStmt, *ast.DoStmt:
+		return false
+	}
+	return true
+}
+
+type table struct {
+	schema string
+	name   string
+}
+
+func checkRowLevelSecurity(stats []statement) []Finding {
+	created := map[table]statement{}
+	var order []table
+	for _, s := range stats {
+		switch v := s.node.(type) {
+		case *ast.CreateStmt:
+			if t := toTable(v.Relation); t.schema == "public" {
+				if _, exists := created[t]; !exists {
+					order = append(order, t)
+				}
+				created[t] = s
+			}
+		case *ast.AlterTableStmt:
+			t := toTable(v.Relation)
+			for _, c := range listItems(v.Cmds) {
+				if cmd, ok := c.(*ast.AlterTableCmd); ok {
+					switch cmd.Subtype {
+					case ast.AT_EnableRowSecurity:
+						delete(created, t)
+					case ast.AT_DisableRowSecurity:
+						created[t] = s
+					}
+				}
+			}
+		case *ast.DropStmt:
+			if v.RemoveType == ast.OBJECT_TABLE {
+				for _, o := range listItems(v.Objects) {
+					if name := getQualifiedName(o); len(name) > 0 {
+						t := table{schema: "public", name: name[len(name)-1]}
+						if len(name) > 1 {
+							t.schema = name[len(name)-2]
+						}
+						delete(created, t)
+					}
+				}
+			}
+		}
+	}
+	var findings []Finding
+	for _, t := range order {
+		if s, ok := created[t]; ok {
+			findings = append(findings, newFinding(RuleRlsDisabled, s, "Table %s.%s does not enable row level security", t.schema, t.name))
+		}
+	}
+	return findings
+}
+
+func toTable(r *ast.RangeVar) table {
+	if r == nil {
+		return table{}
+	}
+	t := table{schema: r.SchemaName, name: r.RelName}
+	if len(t.schema) == 0 {
+		t.schema = "public"
+	}
+	return t
+}
+
+func toRelationName(r *ast.RangeVar) string {
+	t := toTable(r)
+	return t.schema + "." + t.name
+}
+
+func listItems(n *ast.NodeList) []ast.Node {
+	if n == nil {
+		return nil
+	}
+	return n.Items
+}
+
+func getQualifiedName(n ast.Node) []string {
+	switch v := n.(type) {
+	case *ast.NodeList:
+		return toQualifiedName(v)
+	case *ast.RangeVar:
+		t := toTable(v)
+		return []string{t.schema, t.name}
+	case *ast.String:
+		return []string{v.SVal}
+	}
+	return nil
+}
+
+func toQualifiedName(n *ast.NodeList) []string {
+	var r []string
+	for _, v := range listItems(n) {
+		if s, ok := v.(*ast.String); ok {
+			r = append(r, s.SVal)
+		}
+	}
+	return r
+}
+
+func printFindings(findings []Finding, output string, w io.Writer) error {
+	switch output {
+	case utils.OutputJson:
+		if findings == nil {
+			findings = []Finding{}
+		}
+		return utils.EncodeOutput(output, w, findings)
+	case utils.OutputSarif:
+		log := sarif.NewLog("supabase migration lint")
+		for _, r := range Rules {
+			log.AddRule(sarif.Rule{ID: r.ID, Name: r.ID, FullDescription: sarif.NewMessage(r.Description)})
+		}
+		for _, f := range findings {
+			log.AddResult(sarif.Result{
+				RuleID:    f.Rule,
+				Level:     sarif.ToLevel(f.Level),
+				Message:   sarif.Message{Text: f.Message},
+				Locations: []sarif.Location{*sarif.NewLocation(f.File, f.Line)},
+			})
+		}
+		return log.Write(w)
+	}
+	for _, f := range findings {
+		level := utils.Yellow(f.Level)
+		if f.Level == LevelError {
+			level = utils.Red(f.Level)
+		}
+		if _, err := fmt.Fprintf(w, "%s:%d: %s %s [%s]\n", f.File, f.Line, level, f.Message, f.Rule); err != nil {
+			return errors.Errorf("failed to print finding: %w", err)
+		}
+	}
+	return nil
+}
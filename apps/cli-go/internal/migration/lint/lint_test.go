@@ -0,0 +1,173 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/sarif"
+)
+
+func writeFile(t *testing.T, fsys afero.Fs, name, sql string) string {
+	path := filepath.Join(utils.MigrationsDir, name)
+	require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+	return filepath.ToSlash(path)
+}
+
+func TestLintFiles(t *testing.T) {
+	t.Run("checks idempotent files", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		path := writeFile(t, fsys, "20240101000000_init.sql", `-- Idempotent setup
+create schema if not exists private;
+
+create table private.todos (id bigint);
+create or replace view private.todo_view as select * from private.todos;
+`)
+		// Run test
+		findings, err := LintFiles([]string{path}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Finding{{
+			Rule:    RuleMissingIfNotExists.ID,
+			Level:   LevelWarning,
+			Message: "Table private.todos is missing IF NOT EXISTS or OR REPLACE",
+			File:    path,
+			Line:    4,
+		}}, findings)
+	})
+
+	t.Run("checks security definer functions", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		path := writeFile(t, fsys, "20240101000000_init.sql", `create function private.f1() returns void language sql security definer as $$ select 1 $$;
+create function private.f2() returns void language sql security definer set search_path = '' as $$ select 1 $$;
+create function private.f3() returns void language sql as $$ select 1 $$;
+`)
+		// Run test
+		findings, err := LintFiles([]string{path}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, findings, 1)
+		assert.Equal(t, RuleSearchPath.ID, findings[0].Rule)
+		assert.Equal(t, 1, findings[0].Line)
+	})
+
+	t.Run("checks rls across files", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		first := writeFile(t, fsys, "20240101000000_init.sql", `create table todos (id bigint);
+create table public.profiles (id uuid);
+create table private.secrets (id uuid);
+`)
+		second := writeFile(t, fsys, "20240102000000_rls.sql", `alter table public.todos enable row level security;`)
+		// Run test
+		findings, err := LintFiles([]string{first, second}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Finding{{
+			Rule:    RuleRlsDisabled.ID,
+			Level:   LevelError,
+			Message: "Table public.profiles does not enable row level security",
+			File:    first,
+			Line:    2,
+		}}, findings)
+	})
+
+	t.Run("checks grants and mixed statements", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		path := writeFile(t, fsys, "20240101000000_init.sql", `create table private.todos (id bigint);
+grant select on table private.todos to anon, authenticated;
+
+insert into private.todos values (1);
+`)
+		// Run test
+		findings, err := LintFiles([]string{path}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, findings, 2)
+		assert.Equal(t, RuleGrantAnon.ID, findings[0].Rule)
+		assert.Equal(t, 2, findings[0].Line)
+		assert.Equal(t, RuleMixedStatements.ID, findings[1].Rule)
+		assert.Equal(t, 4, findings[1].Line)
+	})
+
+	t.Run("reports unparsable statements", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		path := writeFile(t, fsys, "20240101000000_init.sql", `create schema private;
+
+create tabel private.todos (id bigint);
+`)
+		// Run test
+		findings, err := LintFiles([]string{path}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, findings, 1)
+		assert.Equal(t, RuleUnparsable.ID, findings[0].Rule)
+		assert.Equal(t, LevelWarning, findings[0].Level)
+		assert.Contains(t, findings[0].Message, "Statement could not be parsed")
+		assert.Equal(t, 3, findings[0].Line)
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		_, err := LintFiles([]string{"missing.sql"}, afero.NewMemMapFs())
+		assert.ErrorContains(t, err, "failed to read file")
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("lints local migrations", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		writeFile(t, fsys, "20240101000000_init.sql", "create table todos (id bigint);")
+		// Run test
+		err := Run(context.Background(), nil, OutputText, "error", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "fail-on is set to error, non-zero exit")
+	})
+
+	t.Run("ignores warnings below fail-on level", func(t *testing.T) {
+		fsys := afero.NewMemMapFs()
+		path := writeFile(t, fsys, "20240101000000_init.sql", "grant select on table private.todos to anon;")
+		// Run test
+		err := Run(context.Background(), []string{path}, utils.OutputJson, "error", fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
+
+func TestPrintFindings(t *testing.T) {
+	findings := []Finding{{
+		Rule:    RuleGrantAnon.ID,
+		Level:   LevelWarning,
+		Message: "Privileges are granted to the anon role",
+		File:    "supabase/migrations/20240101000000_init.sql",
+		Line:    2,
+	}}
+
+	t.Run("prints text", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printFindings(findings, OutputText, &out))
+		assert.Contains(t, out.String(), "supabase/migrations/20240101000000_init.sql:2:")
+		assert.Contains(t, out.String(), "[grant_to_anon]")
+	})
+
+	t.Run("prints sarif", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printFindings(findings, utils.OutputSarif, &out))
+		var log sarif.Log
+		require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+		assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(Rules))
+		require.Len(t, log.Runs[0].Results, 1)
+		assert.Equal(t, "warning", log.Runs[0].Results[0].Level)
+		assert.Equal(t, 2, log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	})
+
+	t.Run("prints empty json array", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, printFindings(nil, utils.OutputJson, &out))
+		assert.Equal(t, "[]\n", out.String())
+	})
+}
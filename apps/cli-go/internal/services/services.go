@@ -59,7 +59,7 @@ type imageVersion struct {
 func CheckVersions(ctx context.Context, fsys afero.Fs) []imageVersion {
 	var remote map[string]string
 	if _, err := utils.LoadAccessTokenFS(fsys); err == nil && len(flags.ProjectRef) > 0 {
-		remote = listRemoteImages(ctx, flags.ProjectRef)
+		remote = ListRemoteImages(ctx, flags.ProjectRef)
 	}
 	var result []imageVersion
 	for _, image := range utils.Config.GetServiceImages() {
@@ -76,7 +76,9 @@ func CheckVersions(ctx context.Context, fsys afero.Fs) []imageVersion {
 	return result
 }
 
-func listRemoteImages(ctx context.Context, projectRef string) map[string]string {
+// ListRemoteImages maps local service images to the versions deployed on the
+// linked project. Services that fail to report a version are mapped to empty.
+func ListRemoteImages(ctx context.Context, projectRef string) map[string]string {
 	keys, err := tenant.GetApiKeys(ctx, projectRef)
 	if err != nil {
 		return nil
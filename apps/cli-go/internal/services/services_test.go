@@ -126,7 +126,7 @@ func TestCheckVersions(t *testing.T) {
 			Reply(http.StatusOK).
 			BodyString("1.28.0")
 		// Run test
-		images := listRemoteImages(context.Background(), flags.ProjectRef)
+		images := ListRemoteImages(context.Background(), flags.ProjectRef)
 		// Check error
 		assert.Equal(t, images, map[string]string{
 			utils.Config.Db.Image:      "14.1.0.99",
@@ -0,0 +1,24 @@
+package list
+
+import (
+	"context"
+
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/sms"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+func Run(ctx context.Context, to string, limit uint, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	client, err := sms.NewCaptureClient()
+	if err != nil {
+		return err
+	}
+	messages, err := sms.ListMessages(ctx, client, to, limit)
+	if err != nil {
+		return err
+	}
+	return sms.RenderMessages(messages)
+}
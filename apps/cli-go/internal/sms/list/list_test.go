@@ -0,0 +1,54 @@
+package list
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/sms"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestListMessages(t *testing.T) {
+	t.Run("lists captured sms", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`
+project_id = "test"
+[auth.sms.capture]
+enabled = true
+port = 54328
+`), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1:54328").
+			Get("/messages").
+			MatchParam("limit", "50").
+			Reply(http.StatusOK).
+			JSON([]sms.Message{{
+				To:      "+15555550100",
+				Otp:     "123456",
+				Message: "Your code is 123456",
+			}})
+		// Run test
+		err := Run(context.Background(), "", 50, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on disabled capture", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), "", 50, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "SMS capture is disabled.")
+	})
+}
@@ -0,0 +1,72 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+type Message struct {
+	ID        string    `json:"id"`
+	To        string    `json:"to"`
+	Otp       string    `json:"otp"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func GetCaptureUrl() string {
+	return fmt.Sprintf("http://%s:%d", utils.Config.Hostname, utils.Config.Auth.Sms.Capture.Port)
+}
+
+func NewCaptureClient() (*fetcher.Fetcher, error) {
+	if !utils.Config.Auth.Enabled || !utils.Config.Auth.Sms.Capture.Enabled {
+		return nil, errors.New("SMS capture is disabled. Enable it in your supabase/config.toml under [auth.sms.capture].")
+	}
+	return fetcher.NewFetcher(
+		GetCaptureUrl(),
+		fetcher.WithExpectedStatus(http.StatusOK),
+	), nil
+}
+
+// ListMessages returns captured SMS sent to the phone number, newest first.
+func ListMessages(ctx context.Context, client *fetcher.Fetcher, to string, limit uint) ([]Message, error) {
+	params := url.Values{"limit": {strconv.FormatUint(uint64(limit), 10)}}
+	if len(to) > 0 {
+		params.Set("to", to)
+	}
+	resp, err := client.Send(ctx, http.MethodGet, "/messages?"+params.Encode(), nil)
+	if err != nil {
+		utils.CmdSuggestion = fmt.Sprintf("Make sure your local development setup is running: %s", utils.Aqua("supabase start"))
+		return nil, errors.Errorf("failed to list SMS: %w", err)
+	}
+	return fetcher.ParseJSON[[]Message](resp.Body)
+}
+
+// RenderMessages prints captured SMS as a table, or in the requested output format.
+func RenderMessages(messages []Message) error {
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, messages)
+	}
+	var table strings.Builder
+	table.WriteString(`|TO|OTP|MESSAGE|RECEIVED AT (UTC)|
+|-|-|-|-|
+`)
+	for _, m := range messages {
+		fmt.Fprintf(&table, "|`%s`|`%s`|`%s`|`%s`|\n",
+			m.To,
+			m.Otp,
+			strings.ReplaceAll(m.Message, "|", "\\|"),
+			utils.FormatTime(m.CreatedAt),
+		)
+	}
+	return utils.RenderTable(table.String())
+}
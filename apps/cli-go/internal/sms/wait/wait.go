@@ -0,0 +1,57 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/sms"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+var errNoMatch = errors.New("no matching SMS")
+
+func Run(ctx context.Context, to string, timeout time.Duration, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	client, err := sms.NewCaptureClient()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Waiting for SMS...")
+	msg, err := WaitForMessage(ctx, client, to, timeout)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, msg)
+	}
+	return sms.RenderMessages([]sms.Message{msg})
+}
+
+// WaitForMessage polls the capture container until an SMS to the phone number
+// is received, returning the most recent match.
+func WaitForMessage(ctx context.Context, client *fetcher.Fetcher, to string, timeout time.Duration) (sms.Message, error) {
+	poll := func() (sms.Message, error) {
+		messages, err := sms.ListMessages(ctx, client, to, 1)
+		if err != nil {
+			return sms.Message{}, backoff.Permanent(err)
+		} else if len(messages) == 0 {
+			return sms.Message{}, errNoMatch
+		}
+		return messages[0], nil
+	}
+	msg, err := backoff.RetryWithData(poll, start.NewBackoffPolicy(ctx, timeout))
+	if errors.Is(err, errNoMatch) {
+		return msg, errors.Errorf("timed out after %s waiting for a matching SMS", timeout)
+	}
+	return msg, err
+}
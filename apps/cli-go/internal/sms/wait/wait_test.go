@@ -0,0 +1,55 @@
+package wait
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/sms"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+func TestWaitForMessage(t *testing.T) {
+	captureUrl := sms.GetCaptureUrl()
+	client := fetcher.NewFetcher(captureUrl, fetcher.WithExpectedStatus(http.StatusOK))
+
+	t.Run("polls until sms is captured", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(captureUrl).
+			Get("/messages").
+			MatchParam("to", `\+15555550100`).
+			Reply(http.StatusOK).
+			JSON([]sms.Message{})
+		gock.New(captureUrl).
+			Get("/messages").
+			MatchParam("to", `\+15555550100`).
+			Reply(http.StatusOK).
+			JSON([]sms.Message{{To: "+15555550100", Otp: "123456"}})
+		// Run test
+		msg, err := WaitForMessage(context.Background(), client, "+15555550100", 5*time.Second)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Equal(t, "123456", msg.Otp)
+	})
+
+	t.Run("throws error on timeout", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(captureUrl).
+			Get("/messages").
+			Times(2).
+			Reply(http.StatusOK).
+			JSON([]sms.Message{})
+		// Run test
+		_, err := WaitForMessage(context.Background(), client, "+15555550100", time.Second)
+		// Check error
+		assert.ErrorContains(t, err, "timed out after 1s waiting for a matching SMS")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,27 @@
+package delete
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/snapshot/save"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, name string, fsys afero.Fs) error {
+	dir, err := save.GetSnapshotDir(name)
+	if err != nil {
+		return err
+	}
+	if _, err := save.LoadMetadata(dir, fsys); err != nil {
+		return err
+	}
+	if err := fsys.RemoveAll(dir); err != nil {
+		return errors.Errorf("failed to delete snapshot: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Deleted snapshot:", utils.Aqua(name))
+	return nil
+}
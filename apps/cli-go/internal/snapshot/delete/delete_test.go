@@ -0,0 +1,39 @@
+package delete
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/snapshot/save"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestDeleteSnapshot(t *testing.T) {
+	t.Run("deletes snapshot directory", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		dir := filepath.Join(utils.SnapshotsDir, "fresh")
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, save.MetadataFile), []byte("{}"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, "db.tar.gz"), []byte{}, 0644))
+		// Run test
+		err := Run(context.Background(), "fresh", fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.DirExists(fsys, dir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on missing snapshot", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "fresh", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "snapshot not found: fresh")
+	})
+}
@@ -0,0 +1,76 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/snapshot/save"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type Snapshot struct {
+	save.Metadata
+	Size int64 `json:"size"`
+}
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	snapshots, err := ListSnapshots(fsys)
+	if err != nil {
+		return err
+	}
+	if utils.OutputFormat.Value == utils.OutputPretty {
+		var table strings.Builder
+		table.WriteString(`|NAME|CREATED AT (UTC)|POSTGRES VERSION|VOLUMES|SIZE|
+|-|-|-|-|-|
+`)
+		for _, s := range snapshots {
+			fmt.Fprintf(&table, "|`%s`|`%s`|`%d`|`%s`|`%s`|\n",
+				s.Name,
+				utils.FormatTime(s.CreatedAt),
+				s.MajorVersion,
+				strings.Join(s.Volumes, ", "),
+				units.HumanSize(float64(s.Size)),
+			)
+		}
+		return utils.RenderTable(table.String())
+	}
+	return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, snapshots)
+}
+
+func ListSnapshots(fsys afero.Fs) ([]Snapshot, error) {
+	entries, err := afero.ReadDir(fsys, utils.SnapshotsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read snapshots: %w", err)
+	}
+	var result []Snapshot
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(utils.SnapshotsDir, e.Name())
+		meta, err := save.LoadMetadata(dir, fsys)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		s := Snapshot{Metadata: meta}
+		if err := afero.Walk(fsys, dir, func(path string, info os.FileInfo, err error) error {
+			if err == nil && !info.IsDir() {
+				s.Size += info.Size()
+			}
+			return err
+		}); err != nil {
+			return nil, errors.Errorf("failed to read snapshot size: %w", err)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
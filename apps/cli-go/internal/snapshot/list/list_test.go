@@ -0,0 +1,49 @@
+package list
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/snapshot/save"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestListSnapshots(t *testing.T) {
+	t.Run("lists snapshots with size", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		meta := save.Metadata{
+			Name:         "fresh",
+			CreatedAt:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			MajorVersion: 17,
+			Volumes:      []string{"db"},
+		}
+		data, err := json.Marshal(meta)
+		require.NoError(t, err)
+		dir := filepath.Join(utils.SnapshotsDir, "fresh")
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, save.MetadataFile), data, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, "db.tar.gz"), make([]byte, 1024), 0644))
+		// Ignores directories without metadata
+		require.NoError(t, fsys.MkdirAll(filepath.Join(utils.SnapshotsDir, "broken"), 0755))
+		// Run test
+		snapshots, err := ListSnapshots(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Snapshot{{Metadata: meta, Size: int64(1024 + len(data))}}, snapshots)
+	})
+
+	t.Run("returns empty without snapshots", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		snapshots, err := ListSnapshots(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, snapshots)
+	})
+}
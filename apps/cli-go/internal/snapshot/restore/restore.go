@@ -0,0 +1,56 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/snapshot/save"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+func Run(ctx context.Context, name string, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	dir, err := save.GetSnapshotDir(name)
+	if err != nil {
+		return err
+	}
+	meta, err := save.LoadMetadata(dir, fsys)
+	if err != nil {
+		return err
+	}
+	// Postgres data directories are not compatible across major versions
+	if meta.MajorVersion != utils.Config.Db.MajorVersion {
+		return errors.Errorf("snapshot was saved from Postgres %d but the local database is Postgres %d", meta.MajorVersion, utils.Config.Db.MajorVersion)
+	}
+	var volumes []save.Volume
+	var script []string
+	for _, v := range save.DataVolumes() {
+		if slices.Contains(meta.Volumes, v.Name) {
+			volumes = append(volumes, v)
+			script = append(script, fmt.Sprintf("find /volumes/%[1]s -mindepth 1 -delete && tar -xzpf /snapshot/%[1]s.tar.gz -C /volumes/%[1]s", v.Name))
+		}
+	}
+	// All services hold connections or caches derived from the restored data
+	stopped, err := save.StopContainers(ctx, func(string) bool { return true })
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Restoring snapshot:", utils.Aqua(name))
+	err = save.RunHelper(ctx, dir, volumes, strings.Join(script, " && "))
+	if serr := save.StartContainers(ctx, stopped); err == nil {
+		err = serr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Restored snapshot:", utils.Aqua(name))
+	return nil
+}
@@ -0,0 +1,100 @@
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/snapshot/save"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func writeMetadata(t *testing.T, fsys afero.Fs, meta save.Metadata) {
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+	path := filepath.Join(utils.SnapshotsDir, meta.Name, save.MetadataFile)
+	require.NoError(t, afero.WriteFile(fsys, path, data, 0644))
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.Db.Image)
+	const containerId = "test-helper"
+
+	t.Run("restores and restarts services", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		writeMetadata(t, fsys, save.Metadata{
+			Name:         "demo",
+			MajorVersion: 17,
+			Volumes:      []string{"db", "storage"},
+		})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		utils.UpdateDockerIds()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+			Reply(http.StatusOK).
+			JSON([]container.Summary{
+				{Names: []string{"/" + utils.DbId}},
+				{Names: []string{"/" + utils.GotrueId}},
+			})
+		for _, name := range []string{utils.DbId, utils.GotrueId} {
+			gock.New(utils.Docker.DaemonHost()).
+				Post("/v" + utils.Docker.ClientVersion() + "/containers/" + name + "/stop").
+				Reply(http.StatusOK)
+		}
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, ""))
+		for _, name := range []string{utils.DbId, utils.GotrueId} {
+			gock.New(utils.Docker.DaemonHost()).
+				Post("/v" + utils.Docker.ClientVersion() + "/containers/" + name + "/start").
+				Reply(http.StatusAccepted)
+		}
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId + "/json").
+			Reply(http.StatusOK).
+			JSON(container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				State: &container.State{Running: true},
+			}})
+		// Run test
+		err := Run(context.Background(), "demo", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing snapshot", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), "demo", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "snapshot not found: demo")
+	})
+
+	t.Run("throws error on major version mismatch", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		writeMetadata(t, fsys, save.Metadata{
+			Name:         "demo",
+			MajorVersion: 13,
+			Volumes:      []string{"db"},
+		})
+		// Run test
+		err := Run(context.Background(), "demo", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "snapshot was saved from Postgres 13")
+	})
+}
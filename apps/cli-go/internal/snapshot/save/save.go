@@ -0,0 +1,215 @@
+package save
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+const MetadataFile = "snapshot.json"
+
+type Metadata struct {
+	Name         string    `json:"name"`
+	CreatedAt    time.Time `json:"created_at"`
+	MajorVersion uint      `json:"major_version"`
+	Volumes      []string  `json:"volumes"`
+}
+
+// Volume is a named docker volume that holds local data.
+type Volume struct {
+	Name string
+	Id   string
+}
+
+func DataVolumes() []Volume {
+	return []Volume{
+		{Name: "db", Id: utils.DbId},
+		{Name: "storage", Id: utils.StorageId},
+	}
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+func GetSnapshotDir(name string) (string, error) {
+	if !namePattern.MatchString(name) {
+		return "", errors.Errorf("invalid snapshot name: %s (must match %s)", name, namePattern.String())
+	}
+	return filepath.Join(utils.SnapshotsDir, name), nil
+}
+
+func Run(ctx context.Context, name string, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	dir, err := GetSnapshotDir(name)
+	if err != nil {
+		return err
+	}
+	if _, err := fsys.Stat(dir); err == nil {
+		return errors.Errorf("snapshot already exists: %s", name)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return errors.Errorf("failed to check snapshot: %w", err)
+	}
+	var volumes []Volume
+	for _, v := range DataVolumes() {
+		if _, err := utils.Docker.VolumeInspect(ctx, v.Id); errdefs.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Errorf("failed to inspect volume: %w", err)
+		}
+		volumes = append(volumes, v)
+	}
+	if len(volumes) == 0 {
+		return errors.Errorf("no local data volumes found: run %s first", utils.Aqua("supabase start"))
+	}
+	// Stop services that write to the volumes so that the archive is consistent
+	stopped, err := StopContainers(ctx, func(name string) bool {
+		return name == utils.DbId || name == utils.StorageId
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Saving snapshot:", utils.Aqua(name))
+	var script []string
+	for _, v := range volumes {
+		script = append(script, fmt.Sprintf("tar -czf /snapshot/%[1]s.tar.gz -C /volumes/%[1]s .", v.Name))
+	}
+	err = saveSnapshot(ctx, dir, name, volumes, strings.Join(script, " && "), fsys)
+	if serr := StartContainers(ctx, stopped); err == nil {
+		err = serr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Saved snapshot to "+utils.Bold(dir))
+	return nil
+}
+
+func saveSnapshot(ctx context.Context, dir, name string, volumes []Volume, script string, fsys afero.Fs) error {
+	if err := utils.MkdirIfNotExistFS(fsys, dir); err != nil {
+		return err
+	}
+	if err := RunHelper(ctx, dir, volumes, script); err != nil {
+		if rerr := fsys.RemoveAll(dir); rerr != nil {
+			fmt.Fprintln(os.Stderr, rerr)
+		}
+		return err
+	}
+	meta := Metadata{
+		Name:         name,
+		CreatedAt:    time.Now().UTC(),
+		MajorVersion: utils.Config.Db.MajorVersion,
+	}
+	for _, v := range volumes {
+		meta.Volumes = append(meta.Volumes, v.Name)
+	}
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode snapshot metadata: %w", err)
+	}
+	return utils.WriteFile(filepath.Join(dir, MetadataFile), data, fsys)
+}
+
+// RunHelper mounts the data volumes and snapshot directory into a short lived
+// container. The database image is reused because it is always available locally.
+func RunHelper(ctx context.Context, dir string, volumes []Volume, script string) error {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(utils.CurrentDirAbs, dir)
+	}
+	binds := []string{utils.ToDockerPath(dir) + ":/snapshot"}
+	for _, v := range volumes {
+		binds = append(binds, v.Id+":/volumes/"+v.Name)
+	}
+	return utils.DockerRunOnceWithConfig(
+		ctx,
+		container.Config{
+			Image:      utils.Config.Db.Image,
+			Entrypoint: []string{"sh", "-c", script},
+		},
+		container.HostConfig{Binds: binds},
+		network.NetworkingConfig{},
+		"",
+		os.Stdout,
+		os.Stderr,
+	)
+}
+
+// StopContainers stops running project containers matched by filter and
+// returns their names.
+func StopContainers(ctx context.Context, filter func(name string) bool) ([]string, error) {
+	containers, err := utils.Docker.ContainerList(ctx, container.ListOptions{
+		Filters: utils.CliProjectFilter(utils.Config.ProjectId),
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list containers: %w", err)
+	}
+	var stopped []string
+	for _, c := range containers {
+		if len(c.Names) == 0 {
+			continue
+		}
+		if name := strings.TrimPrefix(c.Names[0], "/"); filter(name) {
+			stopped = append(stopped, name)
+		}
+	}
+	result := utils.WaitAll(stopped, func(name string) error {
+		if err := utils.Docker.ContainerStop(ctx, name, container.StopOptions{}); err != nil {
+			return errors.Errorf("failed to stop container: %w", err)
+		}
+		return nil
+	})
+	return stopped, errors.Join(result...)
+}
+
+// StartContainers starts the database before other containers because most
+// services fail to boot without a healthy database.
+func StartContainers(ctx context.Context, names []string) error {
+	var others []string
+	for _, name := range names {
+		if name != utils.DbId {
+			others = append(others, name)
+			continue
+		}
+		if err := utils.Docker.ContainerStart(ctx, name, container.StartOptions{}); err != nil {
+			return errors.Errorf("failed to start container: %w", err)
+		}
+		if err := start.WaitForHealthyService(ctx, utils.Config.Db.HealthTimeout, name); err != nil {
+			return err
+		}
+	}
+	result := utils.WaitAll(others, func(name string) error {
+		if err := utils.Docker.ContainerStart(ctx, name, container.StartOptions{}); err != nil {
+			return errors.Errorf("failed to start container: %w", err)
+		}
+		return nil
+	})
+	return errors.Join(result...)
+}
+
+func LoadMetadata(dir string, fsys afero.Fs) (Metadata, error) {
+	var meta Metadata
+	data, err := afero.ReadFile(fsys, filepath.Join(dir, MetadataFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return meta, errors.Errorf("snapshot not found: %s", filepath.Base(dir))
+	} else if err != nil {
+		return meta, errors.Errorf("failed to read snapshot metadata: %w", err)
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, errors.Errorf("failed to parse snapshot metadata: %w", err)
+	}
+	return meta, nil
+}
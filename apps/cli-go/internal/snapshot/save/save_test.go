@@ -0,0 +1,112 @@
+package save
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestSaveSnapshot(t *testing.T) {
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.Db.Image)
+	const containerId = "test-helper"
+
+	t.Run("saves db volume", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		utils.UpdateDockerIds()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes/" + utils.DbId).
+			Reply(http.StatusOK).
+			JSON(volume.Volume{Name: utils.DbId})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes/" + utils.StorageId).
+			Reply(http.StatusNotFound)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+			Reply(http.StatusOK).
+			JSON([]container.Summary{
+				{Names: []string{"/" + utils.DbId}},
+				{Names: []string{"/" + utils.KongId}},
+			})
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId + "/stop").
+			Reply(http.StatusOK)
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, ""))
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId + "/start").
+			Reply(http.StatusAccepted)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId + "/json").
+			Reply(http.StatusOK).
+			JSON(container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				State: &container.State{Running: true},
+			}})
+		// Run test
+		err := Run(context.Background(), "fresh", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		data, err := afero.ReadFile(fsys, filepath.Join(utils.SnapshotsDir, "fresh", MetadataFile))
+		require.NoError(t, err)
+		var meta Metadata
+		require.NoError(t, json.Unmarshal(data, &meta))
+		assert.Equal(t, "fresh", meta.Name)
+		assert.Equal(t, []string{"db"}, meta.Volumes)
+		assert.Equal(t, utils.Config.Db.MajorVersion, meta.MajorVersion)
+	})
+
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), "../fresh", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "invalid snapshot name: ../fresh")
+	})
+
+	t.Run("throws error on existing snapshot", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, fsys.MkdirAll(filepath.Join(utils.SnapshotsDir, "fresh"), 0755))
+		// Run test
+		err := Run(context.Background(), "fresh", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "snapshot already exists: fresh")
+	})
+
+	t.Run("throws error on missing volumes", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes/").
+			Times(2).
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), "fresh", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "no local data volumes found")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
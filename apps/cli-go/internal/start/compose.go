@@ -0,0 +1,56 @@
+package start
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+// ExportCompose writes the containers of the local stack as a docker compose
+// project, so that the same stack can be started without the CLI.
+func ExportCompose(ctx context.Context, path string, excludedContainers []string, fsys afero.Fs) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	project, err := ResolveComposeProject(ctx, excludedContainers, fsys)
+	if err != nil {
+		return err
+	}
+	data, err := project.MarshalYAML()
+	if err != nil {
+		return errors.Errorf("failed to encode compose project: %w", err)
+	}
+	if err := utils.WriteFile(path, data, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Exported docker compose project to "+utils.Bold(path))
+	return nil
+}
+
+// ResolveComposeProject builds a compose project from the containers that
+// start would create for the current config, without starting any of them.
+func ResolveComposeProject(ctx context.Context, excludedContainers []string, fsys afero.Fs) (*types.Project, error) {
+	project := types.Project{Name: utils.Config.ProjectId}
+	if err := run(utils.WithComposeProject(ctx, &project), fsys, excludedContainers, localDbConfig()); err != nil {
+		return nil, err
+	}
+	// Wait for the database to be healthy before starting other services
+	if db, ok := project.Services[utils.ServiceName(utils.DbId)]; ok && db.HealthCheck != nil {
+		for name, service := range project.Services {
+			if name != db.Name {
+				service.DependsOn = types.DependsOnConfig{db.Name: {
+					Condition: types.ServiceConditionHealthy,
+					Required:  true,
+				}}
+				project.Services[name] = service
+			}
+		}
+	}
+	return &project, nil
+}
@@ -0,0 +1,102 @@
+package start
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestExportCompose(t *testing.T) {
+	t.Run("exports stack without starting containers", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		// Run test
+		err := ExportCompose(context.Background(), "docker-compose.yml", nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		exists, err := afero.Exists(fsys, "docker-compose.yml")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("resolves services from config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, flags.LoadConfig(fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		// Run test
+		project, err := ResolveComposeProject(context.Background(), []string{"studio"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Equal(t, utils.Config.ProjectId, project.Name)
+		assert.NotContains(t, project.Services, "studio")
+		db := project.Services["db"]
+		assert.Equal(t, utils.DbId, db.ContainerName)
+		assert.Equal(t, []types.ServicePortConfig{{
+			Mode:      "ingress",
+			Target:    5432,
+			Published: "54322",
+			Protocol:  "tcp",
+		}}, db.Ports)
+		assert.Equal(t, utils.DbAliases, db.Networks[utils.NetId].Aliases)
+		assert.Contains(t, project.Volumes, utils.DbId)
+		assert.Contains(t, project.Networks, utils.NetId)
+		assert.NotContains(t, db.Labels, utils.CliProjectLabel)
+		// Other services wait for the database
+		kong := project.Services["kong"]
+		assert.Equal(t, types.ServiceConditionHealthy, kong.DependsOn["db"].Condition)
+	})
+
+	t.Run("skips extension download", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, flags.LoadConfig(fsys))
+		utils.Config.Db.Extensions = map[string]config.DbExtension{"pair": {Pgxn: "pair", Version: "0.1.7"}}
+		t.Cleanup(func() { utils.Config.Db.Extensions = nil })
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		// Run test
+		project, err := ResolveComposeProject(context.Background(), nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		assert.Contains(t, project.Services, "db")
+		exists, err := afero.Exists(fsys, filepath.Join(utils.TempDir, "extensions", "pair"))
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on malformed config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte("malformed"), 0644))
+		// Run test
+		err := ExportCompose(context.Background(), "docker-compose.yml", nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "toml: expected = after a key, but the document ends there")
+		exists, err := afero.Exists(fsys, "docker-compose.yml")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
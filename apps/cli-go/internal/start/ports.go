@@ -0,0 +1,153 @@
+package start
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// updateLocalPorts allocates free host ports and a per-worktree project id
+// when autoPorts is set, otherwise discards those allocated by a previous run.
+// Config is reloaded after either change so that derived urls and docker ids
+// use the actual values.
+func updateLocalPorts(autoPorts bool, fsys afero.Fs) error {
+	if !autoPorts {
+		var removed bool
+		for _, path := range []string{utils.LocalPortsPath, utils.LocalProjectIdPath} {
+			if err := fsys.Remove(path); errors.Is(err, os.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return errors.Errorf("failed to remove %s: %w", path, err)
+			}
+			removed = true
+		}
+		if !removed {
+			return nil
+		}
+		return flags.LoadConfig(fsys)
+	}
+	projectId, err := getWorktreeProjectId(utils.Config.ProjectId)
+	if err != nil {
+		return err
+	}
+	if err := utils.WriteFile(utils.LocalProjectIdPath, []byte(projectId), fsys); err != nil {
+		return err
+	}
+	ports, err := allocatePorts(isPortFree)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(ports)
+	if err != nil {
+		return errors.Errorf("failed to encode local ports: %w", err)
+	}
+	if err := utils.WriteFile(utils.LocalPortsPath, data, fsys); err != nil {
+		return err
+	}
+	return flags.LoadConfig(fsys)
+}
+
+// getWorktreeProjectId suffixes the project id with a hash of the current
+// worktree so that containers, networks and volumes don't collide with other
+// checkouts of the same project.
+func getWorktreeProjectId(projectId string) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", errors.Errorf("failed to get working directory: %w", err)
+	}
+	digest := sha256.Sum256([]byte(cwd))
+	suffix := "_" + hex.EncodeToString(digest[:4])
+	// Project id may already be suffixed by a previous run
+	base := strings.TrimSuffix(projectId, suffix)
+	if maxLength := 40 - len(suffix); len(base) > maxLength {
+		base = base[:maxLength]
+	}
+	return base + suffix, nil
+}
+
+// allocatePorts keeps configured host ports that are free and replaces the
+// rest with ports chosen by the OS.
+func allocatePorts(isFree func(port uint16) bool) (config.LocalPorts, error) {
+	current := utils.Config.Ports()
+	var names []string
+	for name, port := range current {
+		// Zero value means the port is not exposed
+		if *port > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	result := config.LocalPorts{}
+	used := map[uint16]bool{}
+	for _, name := range names {
+		port := *current[name]
+		if used[port] || !isFree(port) {
+			free, err := getFreePort(used, isFree)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(os.Stderr, "Port %d is in use, using %d for %s instead.\n", port, free, name)
+			port = free
+		}
+		used[port] = true
+		result[name] = port
+	}
+	return result, nil
+}
+
+// isPortFree probes the address that docker publishes container ports on.
+func isPortFree(port uint16) bool {
+	value := strconv.FormatUint(uint64(port), 10)
+	if !isLocalHostname(utils.Config.Hostname) {
+		// Remote docker host has the port taken if anything accepts connections
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(utils.Config.Hostname, value), time.Second)
+		if err != nil {
+			return true
+		}
+		_ = conn.Close()
+		return false
+	}
+	// Docker binds published ports on all IPv4 interfaces by default
+	l, err := net.Listen("tcp4", net.JoinHostPort("0.0.0.0", value))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
+
+func isLocalHostname(hostname string) bool {
+	if ip := net.ParseIP(hostname); ip != nil {
+		return ip.IsLoopback() || ip.IsUnspecified()
+	}
+	return hostname == "localhost"
+}
+
+func getFreePort(used map[uint16]bool, isFree func(port uint16) bool) (uint16, error) {
+	for {
+		l, err := net.Listen("tcp4", "0.0.0.0:0")
+		if err != nil {
+			return 0, errors.Errorf("failed to allocate port: %w", err)
+		}
+		port := uint16(l.Addr().(*net.TCPAddr).Port)
+		if err := l.Close(); err != nil {
+			return 0, errors.Errorf("failed to release port: %w", err)
+		}
+		if !used[port] && isFree(port) {
+			return port, nil
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package start
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestAllocatePorts(t *testing.T) {
+	t.Run("replaces ports in use", func(t *testing.T) {
+		utils.Config.Api.Port = 54321
+		utils.Config.Db.Port = 54322
+		utils.Config.Db.ShadowPort = 54321
+		// Run test
+		ports, err := allocatePorts(func(port uint16) bool {
+			return port != 54322
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(54321), ports["api"])
+		assert.NotContains(t, []uint16{0, 54321, 54322}, ports["db"])
+		assert.NotContains(t, []uint16{0, 54321, 54322, ports["db"]}, ports["shadow_db"])
+	})
+
+	t.Run("skips unexposed ports", func(t *testing.T) {
+		utils.Config.Inbucket.SmtpPort = 0
+		// Run test
+		ports, err := allocatePorts(func(port uint16) bool { return true })
+		// Check error
+		assert.NoError(t, err)
+		assert.NotContains(t, ports, "smtp")
+	})
+}
+
+func TestUpdateLocalPorts(t *testing.T) {
+	t.Run("writes allocated ports", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := updateLocalPorts(true, fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, utils.LocalPortsPath)
+		require.NoError(t, err)
+		var ports config.LocalPorts
+		require.NoError(t, json.Unmarshal(data, &ports))
+		// Reloaded config uses the allocated ports
+		assert.Equal(t, ports["api"], utils.Config.Api.Port)
+		assert.Equal(t, ports["db"], utils.Config.Db.Port)
+	})
+
+	t.Run("suffixes project id by worktree", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, flags.LoadConfig(fsys))
+		projectId := utils.Config.ProjectId
+		// Run test
+		err := updateLocalPorts(true, fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, utils.LocalProjectIdPath)
+		require.NoError(t, err)
+		assert.Regexp(t, "^"+projectId+"_[0-9a-f]{8}$", string(data))
+		// Reloaded config namespaces docker resources
+		assert.Equal(t, string(data), utils.Config.ProjectId)
+		assert.Equal(t, "supabase_db_"+string(data), utils.DbId)
+		// Suffix is not repeated on subsequent runs
+		assert.NoError(t, updateLocalPorts(true, fsys))
+		assert.Equal(t, string(data), utils.Config.ProjectId)
+	})
+
+	t.Run("discards previous ports", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, utils.LocalPortsPath, []byte(`{"db":12345}`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, utils.LocalProjectIdPath, []byte("worktree"), 0644))
+		// Run test
+		err := updateLocalPorts(false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, utils.LocalPortsPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = afero.Exists(fsys, utils.LocalProjectIdPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		assert.NotEqual(t, uint16(12345), utils.Config.Db.Port)
+		assert.NotEqual(t, "worktree", utils.Config.ProjectId)
+	})
+}
@@ -0,0 +1,99 @@
+package start
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+)
+
+// Reload applies config changes to a running stack by recreating only the
+// containers whose config differs from the one they were started with.
+func Reload(ctx context.Context, fsys afero.Fs, excludedContainers []string, dryRun bool) error {
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		return err
+	}
+	// Plan changes without touching any containers
+	plan := utils.ReloadPlan{}
+	if err := run(utils.WithReloadPlan(ctx, &plan), fsys, excludedContainers, localDbConfig()); err != nil {
+		return err
+	}
+	removed, err := listObsoleteContainers(ctx, plan.Services())
+	if err != nil {
+		return err
+	}
+	if len(plan.Changed)+len(plan.Created)+len(removed) == 0 {
+		fmt.Fprintf(os.Stderr, "No config changes to apply. Local %s setup is up to date.\n", utils.Aqua("supabase"))
+		return nil
+	}
+	printReloadPlan(os.Stderr, plan, removed)
+	if dryRun {
+		return nil
+	}
+	// Apply the plan, recreating changed containers
+	for _, id := range removed {
+		if err := utils.Docker.ContainerRemove(ctx, id, container.RemoveOptions{Force: true}); err != nil {
+			return errors.Errorf("failed to remove container: %w", err)
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Recreating containers...")
+	apply := utils.ReloadPlan{Apply: true}
+	if err := run(utils.WithReloadPlan(ctx, &apply), fsys, excludedContainers, localDbConfig()); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Reloaded %s local development setup.\n", utils.Aqua("supabase"))
+	return nil
+}
+
+// listObsoleteContainers returns project containers that are no longer part of the stack.
+func listObsoleteContainers(ctx context.Context, services []string) ([]string, error) {
+	resp, err := utils.Docker.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: utils.CliProjectFilter(utils.Config.ProjectId),
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list containers: %w", err)
+	}
+	var result []string
+	for _, c := range resp {
+		if len(c.Names) == 0 {
+			continue
+		}
+		if name := strings.TrimPrefix(c.Names[0], "/"); !slices.Contains(services, name) {
+			result = append(result, name)
+		}
+	}
+	slices.Sort(result)
+	return result, nil
+}
+
+func printReloadPlan(w io.Writer, plan utils.ReloadPlan, removed []string) {
+	fmt.Fprintln(w, "Reload plan:")
+	print := func(action string, ids []string) {
+		if len(ids) == 0 {
+			return
+		}
+		names := make([]string, len(ids))
+		for i, id := range ids {
+			names[i] = utils.ServiceName(id)
+		}
+		fmt.Fprintf(w, "  %-10s %s\n", action, strings.Join(names, ", "))
+	}
+	print("recreate:", plan.Changed)
+	print("create:", plan.Created)
+	print("remove:", removed)
+	if n := len(plan.Unchanged); n > 0 {
+		fmt.Fprintf(w, "  %-10s %d containers\n", "unchanged:", n)
+	}
+}
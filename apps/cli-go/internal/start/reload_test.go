@@ -0,0 +1,209 @@
+package start
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/h2non/gock"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/gen/signingkeys"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestReloadCommand(t *testing.T) {
+	t.Run("throws error if database is not running", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_start/json").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Reload(context.Background(), fsys, []string{}, true)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrNotRunning)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("plans changes without applying on dry run", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_start/json").
+			Persist().
+			Reply(http.StatusOK).
+			JSON(container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					State: &container.State{Running: true},
+				},
+				Config: &container.Config{
+					Labels: map[string]string{utils.ConfigHashLabel: "stale"},
+				},
+			})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/volumes/supabase_db_start").
+			Reply(http.StatusOK).
+			JSON(volume.Volume{})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+			Reply(http.StatusOK).
+			JSON([]container.Summary{
+				{Names: []string{"/supabase_db_start"}},
+				{Names: []string{"/supabase_studio_start"}},
+			})
+		// Run test
+		err := Reload(context.Background(), fsys, ExcludableContainers(), true)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips services with unchanged config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteFile("supabase/config.toml", []byte(`
+			project_id = "test"
+			[auth]
+			signing_keys_path = "./keys.json"
+		`), fsys))
+		testKey, err := signingkeys.GeneratePrivateKey(config.AlgES256)
+		require.NoError(t, err)
+		keys, err := json.Marshal([]config.JWK{*testKey})
+		require.NoError(t, err)
+		require.NoError(t, utils.WriteFile("supabase/keys.json", keys, fsys))
+		// Simulates a new CLI process that signs API keys on load
+		resetKeys := func() {
+			utils.Config.Auth.AnonKey.Value = ""
+			utils.Config.Auth.ServiceRoleKey.Value = ""
+		}
+		t.Cleanup(func() {
+			resetKeys()
+			utils.Config.Auth.SigningKeysPath = ""
+			utils.Config.Auth.SigningKeys = nil
+		})
+		resetKeys()
+		require.NoError(t, flags.LoadConfig(fsys))
+		kong := utils.ShortContainerImageName(config.Images.Kong)
+		rest := utils.ShortContainerImageName(config.Images.Postgrest)
+		excluded := slices.DeleteFunc(ExcludableContainers(), func(name string) bool {
+			return name == kong || name == rest
+		})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		// Start kong to record its config hash
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.KongId + "/json").
+			Reply(http.StatusNotFound)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Api.KongImage) + "/json").
+			Reply(http.StatusOK).
+			JSON(image.InspectResponse{})
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/networks/create").
+			Reply(http.StatusCreated).
+			JSON(network.CreateResponse{})
+		var hash string
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/create").
+			AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+				var body container.Config
+				if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+					return false, err
+				}
+				hash = body.Labels[utils.ConfigHashLabel]
+				return true, nil
+			}).
+			Reply(http.StatusOK).
+			JSON(container.CreateResponse{ID: utils.KongId})
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.KongId + "/start").
+			Reply(http.StatusAccepted)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.KongId + "/json").
+			Reply(http.StatusOK).
+			JSON(container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+				State: &container.State{
+					Running: true,
+					Health:  &container.Health{Status: types.Healthy},
+				},
+			}})
+		apply := utils.ReloadPlan{Apply: true}
+		require.NoError(t, run(utils.WithReloadPlan(context.Background(), &apply), fsys, append(excluded, rest), pgconn.Config{}))
+		require.NotEmpty(t, hash)
+		resetKeys()
+		require.NoError(t, flags.LoadConfig(fsys))
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.KongId + "/json").
+			Reply(http.StatusOK).
+			JSON(container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					State: &container.State{Running: true},
+				},
+				Config: &container.Config{
+					Labels: map[string]string{utils.ConfigHashLabel: hash},
+				},
+			})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.RestId + "/json").
+			Reply(http.StatusOK).
+			JSON(container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					State: &container.State{Running: true},
+				},
+				Config: &container.Config{
+					Labels: map[string]string{utils.ConfigHashLabel: "stale"},
+				},
+			})
+		// Run test
+		plan := utils.ReloadPlan{}
+		err = run(utils.WithReloadPlan(context.Background(), &plan), fsys, excluded, pgconn.Config{})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{utils.KongId}, plan.Unchanged)
+		assert.Equal(t, []string{utils.RestId}, plan.Changed)
+		assert.Empty(t, plan.Created)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestPrintReloadPlan(t *testing.T) {
+	utils.Config.ProjectId = "test"
+	plan := utils.ReloadPlan{
+		Unchanged: []string{"supabase_db_test", "supabase_rest_test"},
+		Changed:   []string{"supabase_auth_test", "supabase_kong_test"},
+		Created:   []string{"supabase_redis_test"},
+	}
+	// Run test
+	var out bytes.Buffer
+	printReloadPlan(&out, plan, []string{"supabase_studio_test"})
+	// Check output
+	assert.Equal(t, `Reload plan:
+  recreate:  auth, kong
+  create:    redis
+  remove:    studio
+  unchanged: 2 containers
+`, out.String())
+}
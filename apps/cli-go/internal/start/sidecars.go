@@ -0,0 +1,122 @@
+package start
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/format"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// startLocalServices starts the user defined containers declared under
+// [local.services] and returns the ids of started containers.
+func startLocalServices(ctx context.Context) ([]string, error) {
+	names := make([]string, 0, len(utils.Config.Local.Services))
+	for name := range utils.Config.Local.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var started []string
+	for _, name := range names {
+		id := utils.GetId(name)
+		if id == utils.DbId || slices.Contains(utils.GetDockerIds(), id) {
+			return started, errors.Errorf("local service name is reserved: %s", name)
+		}
+		containerConfig, hostConfig, err := newLocalServiceConfig(utils.Config.Local.Services[name])
+		if err != nil {
+			return started, err
+		}
+		if _, err := utils.DockerStartUserImage(
+			ctx,
+			containerConfig,
+			hostConfig,
+			network.NetworkingConfig{
+				EndpointsConfig: map[string]*network.EndpointSettings{
+					utils.NetId: {
+						Aliases: []string{name},
+					},
+				},
+			},
+			id,
+		); err != nil {
+			return started, err
+		}
+		started = append(started, id)
+	}
+	return started, nil
+}
+
+func newLocalServiceConfig(service config.LocalService) (container.Config, container.HostConfig, error) {
+	containerConfig := container.Config{
+		Image: service.Image,
+		Cmd:   service.Command,
+	}
+	for k, v := range service.Env {
+		containerConfig.Env = append(containerConfig.Env, k+"="+v)
+	}
+	sort.Strings(containerConfig.Env)
+	if h := service.Healthcheck; h != nil {
+		test := h.Test
+		switch test[0] {
+		case "CMD", "CMD-SHELL", "NONE":
+		default:
+			test = []string{"CMD-SHELL", strings.Join(test, " ")}
+		}
+		containerConfig.Healthcheck = &container.HealthConfig{
+			Test:        test,
+			Interval:    h.Interval,
+			Timeout:     h.Timeout,
+			StartPeriod: h.StartPeriod,
+			Retries:     h.Retries,
+		}
+	}
+	hostConfig := container.HostConfig{
+		RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+		Resources:     utils.ToDockerResources(service.Resources),
+	}
+	exposed, bindings, err := nat.ParsePortSpecs(service.Ports)
+	if err != nil {
+		return containerConfig, hostConfig, errors.Errorf("failed to parse ports: %w", err)
+	}
+	if len(exposed) > 0 {
+		containerConfig.ExposedPorts = exposed
+		hostConfig.PortBindings = bindings
+	}
+	for _, v := range service.Volumes {
+		spec, err := format.ParseVolume(v)
+		if err != nil {
+			return containerConfig, hostConfig, errors.Errorf("failed to parse docker volume: %w", err)
+		}
+		switch spec.Type {
+		case types.VolumeTypeVolume:
+			// Named volumes are scoped to the project like other local data volumes
+			if len(spec.Source) > 0 {
+				spec.Source = utils.GetId(spec.Source)
+			}
+		case types.VolumeTypeBind:
+			// Relative paths are resolved against the supabase directory
+			if !filepath.IsAbs(spec.Source) {
+				spec.Source = filepath.Join(utils.CurrentDirAbs, utils.SupabaseDirPath, spec.Source)
+			}
+			spec.Source = utils.ToDockerPath(spec.Source)
+		}
+		bind := spec.Target
+		if len(spec.Source) > 0 {
+			bind = spec.Source + ":" + bind
+		}
+		if spec.ReadOnly {
+			bind += ":ro"
+		}
+		hostConfig.Binds = append(hostConfig.Binds, bind)
+	}
+	return containerConfig, hostConfig, nil
+}
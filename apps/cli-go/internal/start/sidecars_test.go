@@ -0,0 +1,131 @@
+package start
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/go-connections/nat"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestLocalServiceConfig(t *testing.T) {
+	utils.Config.ProjectId = "test"
+	utils.CurrentDirAbs = "/project"
+
+	t.Run("converts service to container config", func(t *testing.T) {
+		service := config.LocalService{
+			Image:   "redis:7-alpine",
+			Command: []string{"redis-server"},
+			Env:     map[string]string{"B": "2", "A": "1"},
+			Ports:   []string{"6379:6379"},
+			Volumes: []string{"redis-data:/data", "./redis.conf:/etc/redis.conf:ro", "/tmp"},
+			Healthcheck: &config.LocalHealthcheck{
+				Test:     []string{"redis-cli", "ping"},
+				Interval: 5 * time.Second,
+				Retries:  3,
+			},
+		}
+		// Run test
+		containerConfig, hostConfig, err := newLocalServiceConfig(service)
+		// Check error
+		require.NoError(t, err)
+		assert.Equal(t, []string{"A=1", "B=2"}, containerConfig.Env)
+		assert.Equal(t, []string{"CMD-SHELL", "redis-cli ping"}, containerConfig.Healthcheck.Test)
+		assert.Equal(t, nat.PortSet{"6379/tcp": {}}, containerConfig.ExposedPorts)
+		assert.Equal(t, nat.PortMap{"6379/tcp": []nat.PortBinding{{HostPort: "6379"}}}, hostConfig.PortBindings)
+		assert.Equal(t, []string{
+			"supabase_redis-data_test:/data",
+			utils.ToDockerPath(filepath.Join("/project", "supabase", "redis.conf")) + ":/etc/redis.conf:ro",
+			"/tmp",
+		}, hostConfig.Binds)
+	})
+
+	t.Run("keeps explicit healthcheck type", func(t *testing.T) {
+		service := config.LocalService{
+			Image:       "redis:7-alpine",
+			Healthcheck: &config.LocalHealthcheck{Test: []string{"CMD", "redis-cli", "ping"}},
+		}
+		// Run test
+		containerConfig, _, err := newLocalServiceConfig(service)
+		// Check error
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CMD", "redis-cli", "ping"}, containerConfig.Healthcheck.Test)
+	})
+
+	t.Run("throws error on invalid port", func(t *testing.T) {
+		service := config.LocalService{Image: "redis:7-alpine", Ports: []string{"invalid"}}
+		// Run test
+		_, _, err := newLocalServiceConfig(service)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse ports:")
+	})
+}
+
+func TestStartLocalServices(t *testing.T) {
+	utils.Config.ProjectId = "test"
+	utils.UpdateDockerIds()
+
+	t.Run("starts user defined services", func(t *testing.T) {
+		utils.Config.Local.Services = map[string]config.LocalService{
+			"redis": {Image: "redis:7-alpine"},
+		}
+		t.Cleanup(func() { utils.Config.Local.Services = nil })
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, "redis:7-alpine", utils.GetId("redis"))
+		// Run test
+		started, err := startLocalServices(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"supabase_redis_test"}, started)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("labels named volumes with project", func(t *testing.T) {
+		utils.Config.Local.Services = map[string]config.LocalService{
+			"redis": {Image: "redis:7-alpine", Volumes: []string{"redis-data:/data"}},
+		}
+		t.Cleanup(func() { utils.Config.Local.Services = nil })
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		var created volume.CreateOptions
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/volumes/create").
+			AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+				return true, json.NewDecoder(req.Body).Decode(&created)
+			}).
+			Reply(http.StatusCreated).
+			JSON(volume.Volume{})
+		apitest.MockDockerStart(utils.Docker, "redis:7-alpine", utils.GetId("redis"))
+		// Run test
+		_, err := startLocalServices(context.Background())
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "supabase_redis-data_test", created.Name)
+		// Removed by stop --no-backup
+		assert.Equal(t, "test", created.Labels[utils.CliProjectLabel])
+	})
+
+	t.Run("throws error on reserved name", func(t *testing.T) {
+		utils.Config.Local.Services = map[string]config.LocalService{
+			"studio": {Image: "redis:7-alpine"},
+		}
+		t.Cleanup(func() { utils.Config.Local.Services = nil })
+		// Run test
+		_, err := startLocalServices(context.Background())
+		// Check error
+		assert.ErrorContains(t, err, "local service name is reserved: studio")
+	})
+}
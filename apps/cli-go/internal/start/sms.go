@@ -0,0 +1,70 @@
+package start
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	smsCapturePort = 8000
+	// The receiver does not verify signatures, but auth requires a well formed secret.
+	smsCaptureSecret = "v1,whsec_c3VwYWJhc2UtY2xpLWxvY2FsLXNtcy1jYXB0dXJl"
+)
+
+//go:embed templates/sms_capture.ts
+var smsCaptureEmbed string
+
+// smsCaptureEnv points the send-SMS hook of auth at the capture container.
+// Auth only accepts plain http hooks on loopback or docker host addresses, so
+// the hook is routed through the published capture port.
+func smsCaptureEnv() []string {
+	if !utils.Config.Auth.Sms.Capture.Enabled {
+		return nil
+	}
+	return []string{
+		"GOTRUE_HOOK_SEND_SMS_ENABLED=true",
+		fmt.Sprintf("GOTRUE_HOOK_SEND_SMS_URI=http://%s:%d/hook", utils.DinDHost, utils.Config.Auth.Sms.Capture.Port),
+		"GOTRUE_HOOK_SEND_SMS_SECRETS=" + smsCaptureSecret,
+	}
+}
+
+// startSmsCapture runs a small receiver on the edge runtime image that keeps
+// SMS sent by auth in memory and serves them to `supabase sms`.
+func startSmsCapture(ctx context.Context) error {
+	port := nat.Port(fmt.Sprintf("%d/tcp", smsCapturePort))
+	entrypoint := fmt.Sprintf(`cat <<'EOF' > /root/index.ts && edge-runtime start --main-service=/root --port=%d
+%s
+EOF
+`, smsCapturePort, smsCaptureEmbed)
+	_, err := utils.DockerStart(
+		ctx,
+		container.Config{
+			Image:        utils.Config.EdgeRuntime.Image,
+			Env:          []string{"SMS_TEMPLATE=" + utils.Config.Auth.Sms.Template},
+			Entrypoint:   []string{"sh", "-c", entrypoint},
+			ExposedPorts: nat.PortSet{port: {}},
+		},
+		container.HostConfig{
+			PortBindings: nat.PortMap{port: []nat.PortBinding{{
+				HostPort: strconv.FormatUint(uint64(utils.Config.Auth.Sms.Capture.Port), 10),
+			}}},
+			RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+		},
+		network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				utils.NetId: {
+					Aliases: utils.SmsAliases,
+				},
+			},
+		},
+		utils.SmsId,
+	)
+	return err
+}
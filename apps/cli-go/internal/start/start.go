@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"net/url"
 	"os"
@@ -45,7 +46,7 @@ import (
 	"github.com/supabase/cli/pkg/config"
 )
 
-func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignoreHealthCheck bool) error {
+func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignoreHealthCheck, autoPorts bool) error {
 	// Sanity checks.
 	{
 		if err := flags.LoadConfig(fsys); err != nil {
@@ -53,24 +54,21 @@ func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignore
 		}
 		if err := utils.AssertSupabaseDbIsRunning(); err == nil {
 			fmt.Fprintln(os.Stderr, utils.Aqua("supabase start")+" is already running.")
+			fmt.Fprintln(os.Stderr, "Run "+utils.Aqua("supabase reload")+" to apply config changes.")
 			names := status.CustomName{}
 			return status.Run(ctx, names, utils.OutputPretty, fsys)
 		} else if !errors.Is(err, utils.ErrNotRunning) {
 			return err
 		}
+		if err := updateLocalPorts(autoPorts, fsys); err != nil {
+			return err
+		}
 		if err := flags.LoadProjectRef(fsys); err == nil {
 			_ = services.CheckVersions(ctx, fsys)
 		}
 	}
 
-	dbConfig := pgconn.Config{
-		Host:     utils.DbId,
-		Port:     5432,
-		User:     "postgres",
-		Password: utils.Config.Db.Password,
-		Database: "postgres",
-	}
-	if err := run(ctx, fsys, excludedContainers, dbConfig); err != nil {
+	if err := run(ctx, fsys, excludedContainers, localDbConfig()); err != nil {
 		if ignoreHealthCheck && start.IsUnhealthyError(err) {
 			fmt.Fprintln(os.Stderr, err)
 		} else {
@@ -87,6 +85,16 @@ func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignore
 	return nil
 }
 
+func localDbConfig() pgconn.Config {
+	return pgconn.Config{
+		Host:     utils.DbId,
+		Port:     5432,
+		User:     "postgres",
+		Password: utils.Config.Db.Password,
+		Database: "postgres",
+	}
+}
+
 type kongConfig struct {
 	GotrueId      string
 	RestId        string
@@ -113,8 +121,66 @@ var (
 	// Hardcoded configs which match nginxConfigEmbed
 	nginxEmailTemplateDir   = "/home/kong/templates/email"
 	nginxTemplateServerPort = 8088
+
+	//go:embed templates/tls_proxy.conf
+	tlsProxyConfigEmbed    string
+	tlsProxyConfigTemplate = template.Must(template.New("tlsProxyConfig").Parse(tlsProxyConfigEmbed))
+)
+
+type tlsProxy struct {
+	Name     string
+	Port     uint16
+	HostPort uint16
+	Upstream string
+}
+
+// Ports that Kong listens on when terminating TLS for other local services
+const (
+	tlsStudioPort   = 3443
+	tlsInbucketPort = 9443
 )
 
+// getTlsProxies returns the local services that Kong serves over HTTPS when
+// api.tls uses the certificates issued by certs install.
+func getTlsProxies(excluded map[string]bool) []tlsProxy {
+	if !utils.LocalCertsEnabled || isContainerExcluded(utils.Config.Api.KongImage, excluded) {
+		return nil
+	}
+	var result []tlsProxy
+	if utils.Config.Studio.Enabled && !isContainerExcluded(utils.Config.Studio.Image, excluded) {
+		result = append(result, tlsProxy{
+			Name:     "studio",
+			Port:     tlsStudioPort,
+			HostPort: utils.Config.Studio.Port,
+			Upstream: fmt.Sprintf("%s:3000", utils.StudioId),
+		})
+	}
+	if utils.Config.Inbucket.Enabled && !isContainerExcluded(utils.Config.Inbucket.Image, excluded) {
+		result = append(result, tlsProxy{
+			Name:     "mailpit",
+			Port:     tlsInbucketPort,
+			HostPort: utils.Config.Inbucket.Port,
+			Upstream: fmt.Sprintf("%s:8025", utils.InbucketId),
+		})
+	}
+	if utils.Config.EdgeRuntime.Enabled && !isContainerExcluded(utils.Config.EdgeRuntime.Image, excluded) {
+		result = append(result, tlsProxy{
+			Name:     "inspector",
+			Port:     serve.KongInspectorPort,
+			HostPort: utils.Config.EdgeRuntime.InspectorPort,
+			Upstream: fmt.Sprintf("%s:%d", utils.EdgeRuntimeId, serve.DockerRuntimeInspectorPort),
+		})
+	}
+	return result
+}
+
+// isTlsProxied returns true if the service's host port is published by Kong.
+func isTlsProxied(proxies []tlsProxy, name string) bool {
+	return slices.ContainsFunc(proxies, func(p tlsProxy) bool {
+		return p.Name == name
+	})
+}
+
 type vectorConfig struct {
 	ApiKey        string
 	VectorId      string
@@ -224,35 +290,67 @@ func run(ctx context.Context, fsys afero.Fs, excludedContainers []string, dbConf
 		val, ok := excluded[sc.Name]
 		return !val || !ok
 	}
+	// Locked images are verified on container start instead of pulled
+	notLocked := func(sc types.ServiceConfig) bool {
+		_, ok := utils.LockedImages[sc.Image]
+		return !ok
+	}
 
 	jwks, err := utils.Config.Auth.ResolveJWKS(ctx)
 	if err != nil {
 		return err
 	}
 
+	// Reloading a running stack only pulls images of recreated containers
+	plan := utils.ReloadPlanFromContext(ctx)
+	export := utils.ComposeProjectFromContext(ctx)
+	w := io.Writer(os.Stderr)
+	if plan != nil || export != nil {
+		w = io.Discard
+	}
+
 	// TODO: start services using compose up
 	project := types.Project{
 		Name:     "supabase-cli",
-		Services: utils.GetServices().Filter(notExcluded),
+		Services: utils.GetServices().Filter(notExcluded).Filter(notLocked),
 	}
-	if err := pullImagesUsingCompose(ctx, project); err != nil {
-		return err
+	if len(project.Services) > 0 && plan == nil && export == nil {
+		if err := pullImagesUsingCompose(ctx, project); err != nil {
+			return err
+		}
 	}
 
 	// Start Postgres.
-	if dbConfig.Host == utils.DbId {
-		if err := start.StartDatabase(ctx, "", fsys, os.Stderr, options...); err != nil {
+	if dbConfig.Host == utils.DbId && export != nil {
+		// Exported database is initialised by the image entrypoint, without
+		// downloading extensions because no containers are started here
+		if _, err := utils.DockerStart(ctx, start.NewContainerConfig(), start.NewHostConfig(), network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				utils.NetId: {
+					Aliases: utils.DbAliases,
+				},
+			},
+		}, utils.DbId); err != nil {
+			return err
+		}
+	} else if dbConfig.Host == utils.DbId {
+		if err := start.StartDatabase(ctx, "", fsys, w, options...); err != nil {
 			return err
 		}
 	}
 
-	var started []string
+	// Start user defined services after Postgres so they may depend on it
+	started, err := startLocalServices(ctx)
+	if err != nil {
+		return err
+	}
 	isStorageEnabled := utils.Config.Storage.Enabled && !isContainerExcluded(utils.Config.Storage.Image, excluded)
 	isImgProxyEnabled := utils.Config.Storage.ImageTransformation != nil &&
 		utils.Config.Storage.ImageTransformation.Enabled && !isContainerExcluded(utils.Config.Storage.ImgProxyImage, excluded)
 	isS3ProtocolEnabled := utils.Config.Storage.S3Protocol != nil && utils.Config.Storage.S3Protocol.Enabled
 	isVectorBucketsEnabled := utils.Config.Storage.VectorBuckets.Enabled
-	fmt.Fprintln(os.Stderr, "Starting containers...")
+	proxies := getTlsProxies(excluded)
+	fmt.Fprintln(w, "Starting containers...")
 
 	workdir, err := os.Getwd()
 	if err != nil {
@@ -327,6 +425,7 @@ EOF
 					HostPort: strconv.FormatUint(uint64(utils.Config.Analytics.Port), 10),
 				}}},
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Analytics.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -416,6 +515,7 @@ vector --config /etc/vector/vector.yaml
 				Binds:         binds,
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
 				SecurityOpt:   securityOpts,
+				Resources:     utils.ToDockerResources(utils.Config.Analytics.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -511,6 +611,31 @@ vector --config /etc/vector/vector.yaml
 		if utils.Config.Api.Tls.Enabled {
 			dockerPort = 8443
 		}
+		exposedPorts := nat.PortSet{
+			"8000/tcp": {},
+			"8443/tcp": {},
+			nat.Port(fmt.Sprintf("%d/tcp", nginxTemplateServerPort)): {},
+		}
+		portBindings := nat.PortMap{nat.Port(fmt.Sprintf("%d/tcp", dockerPort)): []nat.PortBinding{{
+			HostPort: strconv.FormatUint(uint64(utils.Config.Api.Port), 10),
+		}}}
+		nginxConfig := nginxConfigEmbed
+		if len(proxies) > 0 {
+			var tlsProxyConfigBuf bytes.Buffer
+			if err := tlsProxyConfigTemplate.Option("missingkey=error").Execute(&tlsProxyConfigBuf, proxies); err != nil {
+				return errors.Errorf("failed to exec template: %w", err)
+			}
+			// Insert server blocks before the default Kong config
+			const marker = "    # include default Kong Nginx config"
+			nginxConfig = strings.Replace(nginxConfig, marker, tlsProxyConfigBuf.String()+marker, 1)
+			for _, p := range proxies {
+				port := nat.Port(fmt.Sprintf("%d/tcp", p.Port))
+				exposedPorts[port] = struct{}{}
+				portBindings[port] = []nat.PortBinding{{
+					HostPort: strconv.FormatUint(uint64(p.HostPort), 10),
+				}}
+			}
+		}
 		if _, err := utils.DockerStart(
 			ctx,
 			container.Config{
@@ -538,25 +663,20 @@ cat <<'EOF' > /home/kong/localhost.key && \
 ./docker-entrypoint.sh kong docker-start --nginx-conf /home/kong/custom_nginx.template
 ` + kongConfigBuf.String() + `
 EOF
-` + nginxConfigEmbed + `
+` + nginxConfig + `
 EOF
 ` + string(utils.Config.Api.Tls.CertContent) + `
 EOF
 ` + string(utils.Config.Api.Tls.KeyContent) + `
 EOF
 `},
-				ExposedPorts: nat.PortSet{
-					"8000/tcp": {},
-					"8443/tcp": {},
-					nat.Port(fmt.Sprintf("%d/tcp", nginxTemplateServerPort)): {},
-				},
+				ExposedPorts: exposedPorts,
 			},
 			container.HostConfig{
-				Binds: binds,
-				PortBindings: nat.PortMap{nat.Port(fmt.Sprintf("%d/tcp", dockerPort)): []nat.PortBinding{{
-					HostPort: strconv.FormatUint(uint64(utils.Config.Api.Port), 10),
-				}}},
+				Binds:         binds,
+				PortBindings:  portBindings,
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Api.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -721,6 +841,7 @@ EOF
 				"GOTRUE_HOOK_SEND_SMS_SECRETS="+hook.Secrets.Value,
 			)
 		}
+		env = append(env, smsCaptureEnv()...)
 		if hook := utils.Config.Auth.Hook.SendEmail; hook != nil && hook.Enabled {
 			env = append(
 				env,
@@ -781,6 +902,7 @@ EOF
 			},
 			container.HostConfig{
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Auth.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -798,9 +920,13 @@ EOF
 
 	// Start Mailpit
 	if utils.Config.Inbucket.Enabled && !isContainerExcluded(utils.Config.Inbucket.Image, excluded) {
-		inbucketPortBindings := nat.PortMap{"8025/tcp": []nat.PortBinding{{
-			HostPort: strconv.FormatUint(uint64(utils.Config.Inbucket.Port), 10),
-		}}}
+		inbucketPortBindings := nat.PortMap{}
+		// Web UI is published by Kong when serving over HTTPS
+		if !isTlsProxied(proxies, "mailpit") {
+			inbucketPortBindings["8025/tcp"] = []nat.PortBinding{{
+				HostPort: strconv.FormatUint(uint64(utils.Config.Inbucket.Port), 10),
+			}}
+		}
 		if utils.Config.Inbucket.SmtpPort != 0 {
 			inbucketPortBindings["1025/tcp"] = []nat.PortBinding{{
 				HostPort: strconv.FormatUint(uint64(utils.Config.Inbucket.SmtpPort), 10),
@@ -831,6 +957,7 @@ EOF
 			container.HostConfig{
 				PortBindings:  inbucketPortBindings,
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Inbucket.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -846,6 +973,14 @@ EOF
 		started = append(started, utils.InbucketId)
 	}
 
+	// Start SMS capture.
+	if utils.Config.Auth.Enabled && utils.Config.Auth.Sms.Capture.Enabled && !isContainerExcluded(utils.Config.Auth.Image, excluded) {
+		if err := startSmsCapture(ctx); err != nil {
+			return err
+		}
+		started = append(started, utils.SmsId)
+	}
+
 	// Start Realtime.
 	if utils.Config.Realtime.Enabled && !isContainerExcluded(utils.Config.Realtime.Image, excluded) {
 		if _, err := utils.DockerStart(
@@ -888,6 +1023,7 @@ EOF
 			},
 			container.HostConfig{
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Realtime.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -922,6 +1058,7 @@ EOF
 			},
 			container.HostConfig{
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Api.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -987,6 +1124,7 @@ EOF
 			container.HostConfig{
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
 				Binds:         []string{utils.StorageId + ":" + dockerStoragePath},
+				Resources:     utils.ToDockerResources(utils.Config.Storage.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -1029,6 +1167,7 @@ EOF
 			container.HostConfig{
 				VolumesFrom:   []string{utils.StorageId},
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Storage.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -1076,6 +1215,7 @@ EOF
 			},
 			container.HostConfig{
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Studio.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -1103,6 +1243,13 @@ EOF
 		containerSnippetsPath := utils.ToDockerPath(hostSnippetsPath)
 		binds = append(binds, fmt.Sprintf("%s:%s:rw", hostSnippetsPath, containerSnippetsPath))
 		binds = utils.RemoveDuplicates(binds)
+		studioPortBindings := nat.PortMap{}
+		// Studio is published by Kong when serving over HTTPS
+		if !isTlsProxied(proxies, "studio") {
+			studioPortBindings["3000/tcp"] = []nat.PortBinding{{
+				HostPort: strconv.FormatUint(uint64(utils.Config.Studio.Port), 10),
+			}}
+		}
 		if _, err := utils.DockerStart(
 			ctx,
 			container.Config{
@@ -1138,11 +1285,10 @@ EOF
 				},
 			},
 			container.HostConfig{
-				Binds: binds,
-				PortBindings: nat.PortMap{"3000/tcp": []nat.PortBinding{{
-					HostPort: strconv.FormatUint(uint64(utils.Config.Studio.Port), 10),
-				}}},
+				Binds:         binds,
+				PortBindings:  studioPortBindings,
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Studio.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -1220,6 +1366,7 @@ EOF
 					HostPort: strconv.FormatUint(uint64(utils.Config.Db.Pooler.Port), 10),
 				}}},
 				RestartPolicy: container.RestartPolicy{Name: container.RestartPolicyUnlessStopped},
+				Resources:     utils.ToDockerResources(utils.Config.Db.Pooler.Resources),
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -1235,7 +1382,10 @@ EOF
 		started = append(started, utils.PoolerId)
 	}
 
-	fmt.Fprintln(os.Stderr, "Waiting for health checks...")
+	if utils.IsReloadDryRun(ctx) || export != nil {
+		return nil
+	}
+	fmt.Fprintln(w, "Waiting for health checks...")
 	if utils.NoBackupVolume && slices.Contains(started, utils.StorageId) {
 		if err := start.WaitForHealthyService(ctx, serviceTimeout, utils.StorageId); err != nil {
 			return err
@@ -1267,16 +1417,14 @@ func ExcludableContainers() []string {
 }
 
 func formatMapForEnvConfig(input map[string]string, output *bytes.Buffer) {
-	numOfKeyPairs := len(input)
-	i := 0
-	for k, v := range input {
-		output.WriteString(k)
-		output.WriteString(":")
-		output.WriteString(v)
-		i++
-		if i < numOfKeyPairs {
+	// Sort keys so that container config is stable across runs
+	for i, k := range slices.Sorted(maps.Keys(input)) {
+		if i > 0 {
 			output.WriteString(",")
 		}
+		output.WriteString(k)
+		output.WriteString(":")
+		output.WriteString(input[k])
 	}
 }
 
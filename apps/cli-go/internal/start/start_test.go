@@ -20,6 +20,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/functions/serve"
 	phtelemetry "github.com/supabase/cli/internal/telemetry"
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/utils"
@@ -59,7 +60,7 @@ func TestStartCommand(t *testing.T) {
 		fsys := afero.NewMemMapFs()
 		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte("malformed"), 0644))
 		// Run test
-		err := Run(context.Background(), fsys, []string{}, false)
+		err := Run(context.Background(), fsys, []string{}, false, false)
 		// Check error
 		assert.ErrorContains(t, err, "toml: expected = after a key, but the document ends there")
 	})
@@ -75,7 +76,7 @@ func TestStartCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/containers").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), fsys, []string{}, false)
+		err := Run(context.Background(), fsys, []string{}, false, false)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -111,8 +112,12 @@ func TestStartCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
 			Reply(http.StatusOK).
 			JSON(running)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/json").
+			Reply(http.StatusOK).
+			JSON([]container.Summary{})
 		// Run test
-		err := Run(context.Background(), fsys, []string{}, false)
+		err := Run(context.Background(), fsys, []string{}, false, false)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -441,3 +446,67 @@ func envToMap(env []string) map[string]string {
 	}
 	return result
 }
+
+func TestTlsProxies(t *testing.T) {
+	utils.Config.Studio.Enabled = true
+	utils.Config.Studio.Port = 54323
+	utils.Config.Inbucket.Enabled = false
+	utils.Config.EdgeRuntime.Enabled = true
+	utils.Config.EdgeRuntime.InspectorPort = 8083
+
+	t.Run("proxies web services with local certs", func(t *testing.T) {
+		utils.LocalCertsEnabled = true
+		defer func() { utils.LocalCertsEnabled = false }()
+		// Run test
+		proxies := getTlsProxies(map[string]bool{})
+		// Check output
+		assert.Equal(t, []tlsProxy{{
+			Name:     "studio",
+			Port:     tlsStudioPort,
+			HostPort: 54323,
+			Upstream: utils.StudioId + ":3000",
+		}, {
+			Name:     "inspector",
+			Port:     serve.KongInspectorPort,
+			HostPort: 8083,
+			Upstream: utils.EdgeRuntimeId + ":8083",
+		}}, proxies)
+		assert.True(t, isTlsProxied(proxies, "studio"))
+		assert.False(t, isTlsProxied(proxies, "mailpit"))
+		var buf bytes.Buffer
+		require.NoError(t, tlsProxyConfigTemplate.Execute(&buf, proxies))
+		assert.Contains(t, buf.String(), "listen 0.0.0.0:3443 ssl;")
+		assert.Contains(t, buf.String(), "set $upstream "+utils.StudioId+":3000;")
+	})
+
+	t.Run("skips proxies when kong is excluded", func(t *testing.T) {
+		utils.LocalCertsEnabled = true
+		defer func() { utils.LocalCertsEnabled = false }()
+		excluded := map[string]bool{utils.ShortContainerImageName(utils.Config.Api.KongImage): true}
+		// Run test
+		assert.Empty(t, getTlsProxies(excluded))
+	})
+
+	t.Run("skips proxies without local certs", func(t *testing.T) {
+		assert.Empty(t, getTlsProxies(map[string]bool{}))
+	})
+}
+
+func TestSmsCaptureEnv(t *testing.T) {
+	t.Run("skips disabled capture", func(t *testing.T) {
+		assert.Empty(t, smsCaptureEnv())
+	})
+
+	t.Run("points send sms hook at capture container", func(t *testing.T) {
+		original := utils.Config.Auth.Sms.Capture
+		utils.Config.Auth.Sms.Capture.Enabled = true
+		utils.Config.Auth.Sms.Capture.Port = 54328
+		defer func() { utils.Config.Auth.Sms.Capture = original }()
+		// Run test
+		env := smsCaptureEnv()
+		// Check output
+		assert.Contains(t, env, "GOTRUE_HOOK_SEND_SMS_ENABLED=true")
+		assert.Contains(t, env, "GOTRUE_HOOK_SEND_SMS_URI=http://host.docker.internal:54328/hook")
+		assert.Regexp(t, `^GOTRUE_HOOK_SEND_SMS_SECRETS=v1,whsec_[A-Za-z0-9+/=]{32,88}$`, env[2])
+	})
+}
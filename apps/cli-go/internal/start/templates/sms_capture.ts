@@ -0,0 +1,41 @@
+// Receives SMS from the local auth server's send-SMS hook and keeps them in
+// memory so that phone auth flows can be tested without a provider.
+type Message = {
+  id: string;
+  to: string;
+  otp: string;
+  message: string;
+  created_at: string;
+};
+
+const MAX_MESSAGES = 1000;
+const template = Deno.env.get("SMS_TEMPLATE") || "Your code is {{ .Code }}";
+const messages: Message[] = [];
+
+// Phone numbers are stored by the auth server without the leading plus sign.
+const normalize = (phone: string) => phone.replace(/[^0-9]/g, "");
+
+Deno.serve(async (req: Request) => {
+  const url = new URL(req.url);
+  if (req.method === "POST" && url.pathname === "/hook") {
+    const { user, sms } = await req.json();
+    messages.unshift({
+      id: crypto.randomUUID(),
+      to: `+${normalize(user?.phone ?? "")}`,
+      otp: sms?.otp ?? "",
+      message: template.replace(/\{\{\s*\.Code\s*\}\}/g, sms?.otp ?? ""),
+      created_at: new Date().toISOString(),
+    });
+    messages.splice(MAX_MESSAGES);
+    return Response.json({});
+  }
+  if (req.method === "GET" && url.pathname === "/messages") {
+    const to = normalize(url.searchParams.get("to") ?? "");
+    const limit = Number(url.searchParams.get("limit") ?? MAX_MESSAGES);
+    const result = to
+      ? messages.filter((m) => normalize(m.to) === to)
+      : messages;
+    return Response.json(result.slice(0, limit));
+  }
+  return new Response("Not Found", { status: 404 });
+});
@@ -0,0 +1,68 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// getMemoryUsage returns the memory used by each running container of the
+// current project, keyed by service name.
+func getMemoryUsage(ctx context.Context) ([]OutputItem, error) {
+	resp, err := utils.Docker.ContainerList(ctx, container.ListOptions{
+		Filters: utils.CliProjectFilter(utils.Config.ProjectId),
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list running containers: %w", err)
+	}
+	items := make([]OutputItem, len(resp))
+	errs := make([]error, len(resp))
+	var wg sync.WaitGroup
+	for i, c := range resp {
+		wg.Add(1)
+		go func(i int, c container.Summary) {
+			defer wg.Done()
+			name := c.ID
+			if len(c.Names) > 0 {
+				name = c.Names[0]
+			}
+			usage, err := getContainerMemory(ctx, c.ID)
+			items[i] = OutputItem{Label: utils.ServiceName(name), Value: usage, Type: Text}
+			errs[i] = err
+		}(i, c)
+	}
+	wg.Wait()
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+	return items, errors.Join(errs...)
+}
+
+func getContainerMemory(ctx context.Context, containerId string) (string, error) {
+	resp, err := utils.Docker.ContainerStatsOneShot(ctx, containerId)
+	if err != nil {
+		return "", errors.Errorf("failed to get container stats: %w", err)
+	}
+	defer resp.Body.Close()
+	var stats container.StatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return "", errors.Errorf("failed to parse container stats: %w", err)
+	}
+	usage := stats.MemoryStats.Usage
+	// Page cache is reclaimable so it is excluded like in docker stats
+	cache := stats.MemoryStats.Stats["inactive_file"]
+	if v, ok := stats.MemoryStats.Stats["total_inactive_file"]; ok {
+		cache = v
+	}
+	if cache < usage {
+		usage -= cache
+	}
+	return fmt.Sprintf("%s / %s", units.BytesSize(float64(usage)), units.BytesSize(float64(stats.MemoryStats.Limit))), nil
+}
@@ -7,16 +7,20 @@ import (
 	_ "embed"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/Netflix/go-env"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
 	"github.com/go-errors/errors"
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/tw"
@@ -73,8 +77,13 @@ func (c *CustomName) toValues(exclude ...string) map[string]string {
 			values[c.McpURL] = utils.GetApiUrl("/mcp")
 		}
 	}
+	// Kong terminates TLS for other web services when using locally trusted certs
+	scheme := "http"
+	if kongEnabled && utils.LocalCertsEnabled {
+		scheme = "https"
+	}
 	if studioEnabled {
-		values[c.StudioURL] = fmt.Sprintf("http://%s:%d", utils.Config.Hostname, utils.Config.Studio.Port)
+		values[c.StudioURL] = fmt.Sprintf("%s://%s:%d", scheme, utils.Config.Hostname, utils.Config.Studio.Port)
 	}
 	if authEnabled {
 		values[c.PublishableKey] = utils.Config.Auth.PublishableKey.Value
@@ -84,8 +93,8 @@ func (c *CustomName) toValues(exclude ...string) map[string]string {
 		values[c.ServiceRoleKey] = utils.Config.Auth.ServiceRoleKey.Value
 	}
 	if inbucketEnabled {
-		values[c.MailpitURL] = fmt.Sprintf("http://%s:%d", utils.Config.Hostname, utils.Config.Inbucket.Port)
-		values[c.InbucketURL] = fmt.Sprintf("http://%s:%d", utils.Config.Hostname, utils.Config.Inbucket.Port)
+		values[c.MailpitURL] = fmt.Sprintf("%s://%s:%d", scheme, utils.Config.Hostname, utils.Config.Inbucket.Port)
+		values[c.InbucketURL] = fmt.Sprintf("%s://%s:%d", scheme, utils.Config.Hostname, utils.Config.Inbucket.Port)
 	}
 	if storageEnabled && utils.Config.Storage.S3Protocol != nil && utils.Config.Storage.S3Protocol.Enabled {
 		values[c.StorageS3URL] = utils.GetApiUrl("/storage/v1/s3")
@@ -111,12 +120,17 @@ func Run(ctx context.Context, names CustomName, format string, fsys afero.Fs) er
 	if len(stopped) > 0 {
 		fmt.Fprintln(os.Stderr, "Stopped services:", stopped)
 	}
+	usage, err := getMemoryUsage(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), err)
+	}
 	if format == utils.OutputPretty {
 		fmt.Fprintf(os.Stderr, "%s local development setup is running.\n\n", utils.Aqua("supabase"))
 		PrettyPrint(os.Stdout, stopped...)
-		return nil
+		group := OutputGroup{Name: "📊 Memory Usage", Items: usage}
+		return group.printTable(os.Stdout)
 	}
-	return printStatus(names, format, os.Stdout, stopped...)
+	return printStatus(names, format, os.Stdout, usage, stopped...)
 }
 
 func checkServiceHealth(ctx context.Context) ([]string, error) {
@@ -133,7 +147,8 @@ func checkServiceHealth(ctx context.Context) ([]string, error) {
 		}
 	}
 	var stopped []string
-	for _, containerId := range utils.GetDockerIds() {
+	ids := append(utils.GetDockerIds(), utils.GetLocalServiceIds()...)
+	for _, containerId := range ids {
 		if _, ok := running["/"+containerId]; !ok {
 			stopped = append(stopped, containerId)
 		}
@@ -225,8 +240,13 @@ func checkHTTPHead(ctx context.Context, path string) error {
 	return nil
 }
 
-func printStatus(names CustomName, format string, w io.Writer, exclude ...string) (err error) {
+func printStatus(names CustomName, format string, w io.Writer, usage []OutputItem, exclude ...string) (err error) {
 	values := names.toValues(exclude...)
+	// Memory usage is keyed by service name, ie. MEMORY_USAGE_DB
+	for _, item := range usage {
+		key := strings.ToUpper(strings.ReplaceAll(item.Label, "-", "_"))
+		values["MEMORY_USAGE_"+key] = item.Value
+	}
 	return utils.EncodeOutput(format, w, values)
 }
 
@@ -280,6 +300,9 @@ func PrettyPrint(w io.Writer, exclude ...string) {
 			},
 		},
 	}
+	if services := localServiceItems(exclude...); len(services) > 0 {
+		groups = append(groups, OutputGroup{Name: "🧩 Local Services", Items: services})
+	}
 
 	for _, group := range groups {
 		if err := group.printTable(w); err != nil {
@@ -290,6 +313,37 @@ func PrettyPrint(w io.Writer, exclude ...string) {
 	}
 }
 
+// localServiceItems lists the published host ports of running user defined services.
+func localServiceItems(exclude ...string) []OutputItem {
+	var items []OutputItem
+	for name, service := range utils.Config.Local.Services {
+		if slices.Contains(exclude, utils.GetId(name)) {
+			continue
+		}
+		_, bindings, err := nat.ParsePortSpecs(service.Ports)
+		if err != nil {
+			continue
+		}
+		var addrs []string
+		for _, binding := range bindings {
+			for _, b := range binding {
+				if len(b.HostPort) > 0 {
+					addrs = append(addrs, net.JoinHostPort(utils.Config.Hostname, b.HostPort))
+				}
+			}
+		}
+		if len(addrs) == 0 {
+			addrs = append(addrs, "running")
+		}
+		sort.Strings(addrs)
+		items = append(items, OutputItem{Label: name, Value: strings.Join(addrs, ", "), Type: Text})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+	return items
+}
+
 type OutputType string
 
 const (
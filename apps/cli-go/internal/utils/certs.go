@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	LocalCaName     = "Supabase CLI Local CA"
+	LocalCaCertFile = "rootCA.pem"
+	LocalCaKeyFile  = "rootCA-key.pem"
+	LocalCertFile   = "localhost.pem"
+	LocalKeyFile    = "localhost-key.pem"
+)
+
+// LocalCertsEnabled is set when api.tls uses the certificates issued by
+// certs install, in which case Kong terminates TLS for all local services.
+var LocalCertsEnabled bool
+
+func GetLocalCertsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Errorf("failed to get $HOME directory: %w", err)
+	}
+	return filepath.Join(home, ".supabase", "certs"), nil
+}
+
+// LoadLocalCerts replaces the bundled self-signed Kong certificate with the
+// locally trusted one, unless a custom certificate is configured.
+func LoadLocalCerts(fsys afero.Fs) error {
+	LocalCertsEnabled = false
+	tls := &Config.Api.Tls
+	if !Config.Api.Enabled || !tls.Enabled || len(tls.CertPath) > 0 || len(tls.KeyPath) > 0 {
+		return nil
+	}
+	dir, err := GetLocalCertsDir()
+	if err != nil {
+		return err
+	}
+	cert, err := afero.ReadFile(fsys, filepath.Join(dir, LocalCertFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to read local cert: %w", err)
+	}
+	key, err := afero.ReadFile(fsys, filepath.Join(dir, LocalKeyFile))
+	if err != nil {
+		return errors.Errorf("failed to read local key: %w", err)
+	}
+	tls.CertContent = cert
+	tls.KeyContent = key
+	LocalCertsEnabled = true
+	return nil
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLocalCerts(t *testing.T) {
+	t.Setenv("HOME", "/home/test")
+	dir := filepath.Join("/home/test", ".supabase", "certs")
+	original := Config.Api
+	t.Cleanup(func() {
+		Config.Api = original
+		LocalCertsEnabled = false
+	})
+	Config.Api.Enabled = true
+	Config.Api.Tls.Enabled = true
+
+	t.Run("uses installed certificates", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, LocalCertFile), []byte("cert"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, LocalKeyFile), []byte("key"), 0600))
+		// Run test
+		err := LoadLocalCerts(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, LocalCertsEnabled)
+		assert.Equal(t, []byte("cert"), Config.Api.Tls.CertContent)
+		assert.Equal(t, []byte("key"), Config.Api.Tls.KeyContent)
+	})
+
+	t.Run("skips custom certificates", func(t *testing.T) {
+		Config.Api.Tls.CertPath = "cert.pem"
+		defer func() { Config.Api.Tls.CertPath = "" }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, LocalCertFile), []byte("cert"), 0644))
+		// Run test
+		err := LoadLocalCerts(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, LocalCertsEnabled)
+	})
+
+	t.Run("skips missing certificates", func(t *testing.T) {
+		// Run test
+		err := LoadLocalCerts(afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, LocalCertsEnabled)
+	})
+
+	t.Run("throws error on missing key", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(dir, LocalCertFile), []byte("cert"), 0644))
+		// Run test
+		err := LoadLocalCerts(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to read local key:")
+	})
+}
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/v2/format"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/go-errors/errors"
+)
+
+type composeProjectContextKey struct{}
+
+// WithComposeProject records containers into project instead of starting them.
+func WithComposeProject(ctx context.Context, project *types.Project) context.Context {
+	return context.WithValue(ctx, composeProjectContextKey{}, project)
+}
+
+func ComposeProjectFromContext(ctx context.Context) *types.Project {
+	project, _ := ctx.Value(composeProjectContextKey{}).(*types.Project)
+	return project
+}
+
+// ServiceName strips the project specific prefix and suffix from a container name.
+func ServiceName(containerName string) string {
+	name := strings.TrimPrefix(containerName, "/")
+	name = strings.TrimPrefix(name, "supabase_")
+	return strings.TrimSuffix(name, "_"+Config.ProjectId)
+}
+
+// addComposeService records the container that would be started as a compose service.
+func addComposeService(project *types.Project, config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig, containerName string) error {
+	setContainerDefaults(&config, &hostConfig)
+	service, err := toServiceConfig(config, hostConfig, networkingConfig, containerName)
+	if err != nil {
+		return err
+	}
+	if project.Services == nil {
+		project.Services = types.Services{}
+	}
+	for name := range service.Networks {
+		if project.Networks == nil {
+			project.Networks = types.Networks{}
+		}
+		project.Networks[name] = types.NetworkConfig{Name: name}
+	}
+	for _, v := range service.Volumes {
+		if v.Type != types.VolumeTypeVolume {
+			continue
+		}
+		if project.Volumes == nil {
+			project.Volumes = types.Volumes{}
+		}
+		project.Volumes[v.Source] = types.VolumeConfig{Name: v.Source}
+	}
+	project.Services[service.Name] = service
+	return nil
+}
+
+func toServiceConfig(config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig, containerName string) (types.ServiceConfig, error) {
+	service := types.ServiceConfig{
+		Name:          ServiceName(containerName),
+		ContainerName: containerName,
+		Image:         config.Image,
+		Hostname:      config.Hostname,
+		User:          config.User,
+		WorkingDir:    config.WorkingDir,
+		Entrypoint:    escapeAll(config.Entrypoint),
+		Command:       escapeAll(config.Cmd),
+		Restart:       string(hostConfig.RestartPolicy.Name),
+		SecurityOpt:   hostConfig.SecurityOpt,
+		MemLimit:      types.UnitBytes(hostConfig.Memory),
+		CPUS:          float32(hostConfig.NanoCPUs) / float32(time.Second),
+	}
+	if len(config.Env) > 0 {
+		service.Environment = types.MappingWithEquals{}
+		for _, kv := range config.Env {
+			key, value, _ := strings.Cut(kv, "=")
+			value = escapeDollar(value)
+			service.Environment[key] = &value
+		}
+	}
+	for k, v := range config.Labels {
+		if k == CliProjectLabel || k == ConfigHashLabel || k == composeProjectLabel {
+			continue
+		}
+		if service.Labels == nil {
+			service.Labels = types.Labels{}
+		}
+		service.Labels[k] = v
+	}
+	if h := config.Healthcheck; h != nil && len(h.Test) > 0 {
+		service.HealthCheck = &types.HealthCheckConfig{
+			Test:        escapeAll(h.Test),
+			Interval:    toDuration(h.Interval),
+			Timeout:     toDuration(h.Timeout),
+			StartPeriod: toDuration(h.StartPeriod),
+		}
+		if h.Retries > 0 {
+			retries := uint64(h.Retries)
+			service.HealthCheck.Retries = &retries
+		}
+	}
+	var err error
+	if service.ExtraHosts, err = types.NewHostsList(hostConfig.ExtraHosts); err != nil {
+		return service, errors.Errorf("failed to parse extra hosts: %w", err)
+	}
+	for _, dst := range slices.Sorted(maps.Keys(hostConfig.Tmpfs)) {
+		if opts := hostConfig.Tmpfs[dst]; len(opts) > 0 {
+			dst += ":" + opts
+		}
+		service.Tmpfs = append(service.Tmpfs, dst)
+	}
+	for _, bind := range hostConfig.Binds {
+		spec, err := format.ParseVolume(bind)
+		if err != nil {
+			return service, errors.Errorf("failed to parse docker volume: %w", err)
+		}
+		service.Volumes = append(service.Volumes, spec)
+	}
+	for port, bindings := range hostConfig.PortBindings {
+		for _, b := range bindings {
+			service.Ports = append(service.Ports, types.ServicePortConfig{
+				Mode:      "ingress",
+				HostIP:    b.HostIP,
+				Target:    uint32(port.Int()),
+				Published: b.HostPort,
+				Protocol:  port.Proto(),
+			})
+		}
+	}
+	sort.Slice(service.Ports, func(i, j int) bool {
+		return service.Ports[i].Target < service.Ports[j].Target
+	})
+	// Endpoint aliases apply to the network that the container joins
+	var aliases []string
+	for _, endpoint := range networkingConfig.EndpointsConfig {
+		aliases = append(aliases, endpoint.Aliases...)
+	}
+	service.Networks = map[string]*types.ServiceNetworkConfig{
+		string(hostConfig.NetworkMode): {Aliases: aliases},
+	}
+	return service, nil
+}
+
+func toDuration(d time.Duration) *types.Duration {
+	if d == 0 {
+		return nil
+	}
+	result := types.Duration(d)
+	return &result
+}
+
+// escapeDollar prevents compose from interpolating shell variables in scripts.
+func escapeDollar(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
+
+func escapeAll(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = escapeDollar(v)
+	}
+	return result
+}
@@ -6,9 +6,11 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 
 	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/docker/api/types/container"
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
@@ -32,6 +34,7 @@ var (
 	LogflareId    string
 	VectorId      string
 	PoolerId      string
+	SmsId         string
 
 	DbAliases          = []string{"db", "db.supabase.internal"}
 	KongAliases        = []string{"kong", "api.supabase.internal"}
@@ -47,6 +50,7 @@ var (
 	LogflareAliases    = []string{"analytics"}
 	VectorAliases      = []string{"vector"}
 	PoolerAliases      = []string{"pooler"}
+	SmsAliases         = []string{"sms"}
 
 	//go:embed templates/initial_schemas/13.sql
 	InitialSchemaPg13Sql string
@@ -77,6 +81,7 @@ func UpdateDockerIds() {
 	LogflareId = GetId(LogflareAliases[0])
 	VectorId = GetId(VectorAliases[0])
 	PoolerId = GetId(PoolerAliases[0])
+	SmsId = GetId(SmsAliases[0])
 }
 
 func GetDockerIds() []string {
@@ -94,9 +99,28 @@ func GetDockerIds() []string {
 		LogflareId,
 		VectorId,
 		PoolerId,
+		SmsId,
 	}
 }
 
+// ToDockerResources converts service resource limits from config.
+func ToDockerResources(r *config.Resources) container.Resources {
+	return container.Resources{
+		Memory:   r.MemoryBytes(),
+		NanoCPUs: r.NanoCpus(),
+	}
+}
+
+// GetLocalServiceIds returns the container ids of user defined services.
+func GetLocalServiceIds() []string {
+	ids := make([]string, 0, len(Config.Local.Services))
+	for name := range Config.Local.Services {
+		ids = append(ids, GetId(name))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 var Config = config.NewConfig(config.WithHostname(GetHostname()))
 
 func GetServices() types.Services {
@@ -190,6 +214,13 @@ func GetServices() types.Services {
 			PullPolicy: types.PullPolicyMissing,
 		}
 	}
+	for name, service := range Config.Local.Services {
+		services["local_"+name] = types.ServiceConfig{
+			Name:       ShortContainerImageName(service.Image),
+			Image:      service.Image,
+			PullPolicy: types.PullPolicyMissing,
+		}
+	}
 	return services
 }
 
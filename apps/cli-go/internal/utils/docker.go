@@ -237,6 +237,9 @@ func DockerImagePullWithRetry(ctx context.Context, image string, retries int) er
 
 func DockerPullImageIfNotCached(ctx context.Context, imageName string) error {
 	imageUrl := GetRegistryImageUrl(imageName)
+	if id, ok := LockedImages[imageUrl]; ok {
+		return AssertLockedImage(ctx, imageUrl, id)
+	}
 	if _, err := Docker.ImageInspect(ctx, imageUrl); err == nil {
 		return nil
 	} else if !errdefs.IsNotFound(err) {
@@ -248,6 +251,13 @@ func DockerPullImageIfNotCached(ctx context.Context, imageName string) error {
 var suggestDockerInstall = "Docker Desktop is a prerequisite for local development. Follow the official docs to install: https://docs.docker.com/desktop"
 
 func DockerStart(ctx context.Context, config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig, containerName string) (string, error) {
+	if skip, err := reconcileContainer(ctx, &config, hostConfig, networkingConfig, containerName); err != nil || skip {
+		return containerName, err
+	}
+	if project := ComposeProjectFromContext(ctx); project != nil {
+		config.Image = GetRegistryImageUrl(config.Image)
+		return containerName, addComposeService(project, config, hostConfig, networkingConfig, containerName)
+	}
 	// Pull container image
 	if err := DockerPullImageIfNotCached(ctx, config.Image); err != nil {
 		if client.IsErrConnectionFailed(err) {
@@ -255,8 +265,39 @@ func DockerStart(ctx context.Context, config container.Config, hostConfig contai
 		}
 		return "", err
 	}
-	// Setup default config
 	config.Image = GetRegistryImageUrl(config.Image)
+	return dockerCreateAndStart(ctx, config, hostConfig, networkingConfig, containerName)
+}
+
+// DockerStartUserImage starts a container from a user provided image, which
+// is pulled from its own registry instead of the Supabase mirror.
+func DockerStartUserImage(ctx context.Context, config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig, containerName string) (string, error) {
+	if skip, err := reconcileContainer(ctx, &config, hostConfig, networkingConfig, containerName); err != nil || skip {
+		return containerName, err
+	}
+	if project := ComposeProjectFromContext(ctx); project != nil {
+		return containerName, addComposeService(project, config, hostConfig, networkingConfig, containerName)
+	}
+	if id, ok := LockedImages[config.Image]; ok {
+		if err := AssertLockedImage(ctx, config.Image, id); err != nil {
+			return "", err
+		}
+	} else if _, err := Docker.ImageInspect(ctx, config.Image); errdefs.IsNotFound(err) {
+		if err := DockerImagePullWithRetry(ctx, config.Image, 2); err != nil {
+			return "", err
+		}
+	} else if err != nil {
+		if client.IsErrConnectionFailed(err) {
+			CmdSuggestion = suggestDockerInstall
+		}
+		return "", errors.Errorf("failed to inspect docker image: %w", err)
+	}
+	return dockerCreateAndStart(ctx, config, hostConfig, networkingConfig, containerName)
+}
+
+// setContainerDefaults labels the container with the current project and
+// attaches it to the project network.
+func setContainerDefaults(config *container.Config, hostConfig *container.HostConfig) {
 	if config.Labels == nil {
 		config.Labels = make(map[string]string, 2)
 	}
@@ -269,6 +310,10 @@ func DockerStart(ctx context.Context, config container.Config, hostConfig contai
 	} else if len(hostConfig.NetworkMode) == 0 {
 		hostConfig.NetworkMode = container.NetworkMode(NetId)
 	}
+}
+
+func dockerCreateAndStart(ctx context.Context, config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig, containerName string) (string, error) {
+	setContainerDefaults(&config, &hostConfig)
 	if err := DockerNetworkCreateIfNotExists(ctx, hostConfig.NetworkMode, config.Labels); err != nil {
 		return "", err
 	}
@@ -3,6 +3,7 @@ package utils
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
@@ -104,6 +105,39 @@ func TestPullImage(t *testing.T) {
 		assert.ErrorContains(t, err, "no space left on device")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+
+	t.Run("throws error if locked image is missing", func(t *testing.T) {
+		LockedImages = ImageLock{imageId: "sha256:locked"}
+		t.Cleanup(func() { LockedImages = nil })
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		gock.New(Docker.DaemonHost()).
+			Get("/v" + Docker.ClientVersion() + "/images/" + imageId + "/json").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := DockerPullImageIfNotCached(context.Background(), imageId)
+		// Validate api
+		assert.ErrorContains(t, err, "locked image not found: "+imageId)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error if locked image does not match", func(t *testing.T) {
+		LockedImages = ImageLock{imageId: "sha256:locked"}
+		t.Cleanup(func() { LockedImages = nil })
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		gock.New(Docker.DaemonHost()).
+			Get("/v" + Docker.ClientVersion() + "/images/" + imageId + "/json").
+			Reply(http.StatusOK).
+			JSON(image.InspectResponse{ID: "sha256:other"})
+		// Run test
+		err := DockerPullImageIfNotCached(context.Background(), imageId)
+		// Validate api
+		assert.ErrorContains(t, err, "expected sha256:locked but found sha256:other")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
 }
 
 func TestRunOnce(t *testing.T) {
@@ -123,6 +157,41 @@ func TestRunOnce(t *testing.T) {
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("runs locked image without repo digest", func(t *testing.T) {
+		LockedImages = ImageLock{imageId: "sha256:locked"}
+		t.Cleanup(func() { LockedImages = nil })
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		gock.New(Docker.DaemonHost()).
+			Get("/v" + Docker.ClientVersion() + "/images/" + imageId + "/json").
+			Reply(http.StatusOK).
+			JSON(image.InspectResponse{ID: "sha256:locked", RepoDigests: []string{}})
+		gock.New(Docker.DaemonHost()).
+			Post("/v" + Docker.ClientVersion() + "/networks/create").
+			Reply(http.StatusCreated).
+			JSON(network.CreateResponse{})
+		gock.New(Docker.DaemonHost()).
+			Post("/v" + Docker.ClientVersion() + "/containers/create").
+			AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+				var body container.Config
+				err := json.NewDecoder(req.Body).Decode(&body)
+				return body.Image == imageId, err
+			}).
+			Reply(http.StatusOK).
+			JSON(container.CreateResponse{ID: containerId})
+		gock.New(Docker.DaemonHost()).
+			Post("/v" + Docker.ClientVersion() + "/containers/" + containerId + "/start").
+			Reply(http.StatusAccepted)
+		require.NoError(t, apitest.MockDockerLogs(Docker, containerId, "hello world"))
+		// Run test
+		out, err := DockerRunOnce(context.Background(), imageId, nil, nil)
+		assert.NoError(t, err)
+		// Validate api
+		assert.Equal(t, "hello world", out)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
 	t.Run("throws error on container create", func(t *testing.T) {
 		// Setup mock docker
 		require.NoError(t, apitest.MockDocker(Docker))
@@ -13,6 +13,12 @@ func LoadConfig(fsys afero.Fs) error {
 		return err
 	}
 	utils.UpdateDockerIds()
+	if err := utils.LoadImageLock(fsys); err != nil {
+		return err
+	}
+	if err := utils.LoadLocalCerts(fsys); err != nil {
+		return err
+	}
 	// Apply profile specific overrides
 	if strings.EqualFold(utils.CurrentProfile.Name, "snap") {
 		ext := utils.Config.Auth.External["snapchat"]
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/containerd/errdefs"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+)
+
+// ImageLock maps image references to the ids of local images that were
+// resolved when the lockfile was written.
+type ImageLock map[string]string
+
+// LockedImages is loaded from ImageLockPath together with config. Locked
+// images are never pulled from the registry.
+var LockedImages ImageLock
+
+func LoadImageLock(fsys afero.Fs) error {
+	LockedImages = nil
+	data, err := afero.ReadFile(fsys, ImageLockPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to read image lock: %w", err)
+	}
+	if err := json.Unmarshal(data, &LockedImages); err != nil {
+		return errors.Errorf("failed to parse image lock: %w", err)
+	}
+	return nil
+}
+
+func WriteImageLock(lock ImageLock, fsys afero.Fs) error {
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to encode image lock: %w", err)
+	}
+	return WriteFile(ImageLockPath, append(data, '\n'), fsys)
+}
+
+// AssertLockedImage checks that the local image matches its locked id
+// without contacting the registry.
+func AssertLockedImage(ctx context.Context, imageUrl, id string) error {
+	resp, err := Docker.ImageInspect(ctx, imageUrl)
+	if errdefs.IsNotFound(err) {
+		return errors.Errorf("locked image not found: %s\nRun %s to load images from a bundle.", imageUrl, Aqua("supabase images load"))
+	} else if err != nil {
+		return errors.Errorf("failed to inspect docker image: %w", err)
+	}
+	if resp.ID != id {
+		return errors.Errorf("image %s does not match %s: expected %s but found %s", imageUrl, Bold(ImageLockPath), id, resp.ID)
+	}
+	return nil
+}
+
+// AssertLockedImages checks every image in the lockfile.
+func AssertLockedImages(ctx context.Context) error {
+	refs := make([]string, 0, len(LockedImages))
+	for ref := range LockedImages {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	var errs []error
+	for _, ref := range refs {
+		if err := AssertLockedImage(ctx, ref, LockedImages[ref]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -81,10 +81,14 @@ var (
 	SupabaseDirPath      = "supabase"
 	ConfigPath           = filepath.Join(SupabaseDirPath, "config.toml")
 	GitIgnorePath        = filepath.Join(SupabaseDirPath, ".gitignore")
+	ImageLockPath        = filepath.Join(SupabaseDirPath, "images.lock.json")
+	VersionLockPath      = filepath.Join(SupabaseDirPath, "versions.lock.json")
 	TempDir              = filepath.Join(SupabaseDirPath, ".temp")
 	ImportMapsDir        = filepath.Join(TempDir, "import_maps")
 	ProjectRefPath       = filepath.Join(TempDir, "project-ref")
 	PoolerUrlPath        = filepath.Join(TempDir, "pooler-url")
+	LocalPortsPath       = filepath.Join(TempDir, "ports.json")
+	LocalProjectIdPath   = filepath.Join(TempDir, "project-id")
 	PostgresVersionPath  = filepath.Join(TempDir, "postgres-version")
 	GotrueVersionPath    = filepath.Join(TempDir, "gotrue-version")
 	RestVersionPath      = filepath.Join(TempDir, "rest-version")
@@ -96,6 +100,9 @@ var (
 	RealtimeVersionPath  = filepath.Join(TempDir, "realtime-version")
 	PgDeltaVersionPath   = filepath.Join(TempDir, "pgdelta-version")
 	CliVersionPath       = filepath.Join(TempDir, "cli-latest")
+	FunctionHashesPath   = filepath.Join(TempDir, "function-hashes.json")
+	StatementsDir        = filepath.Join(TempDir, "statements")
+	SnapshotsDir         = filepath.Join(TempDir, "snapshots")
 	CurrBranchPath       = filepath.Join(SupabaseDirPath, ".branches", "_current_branch")
 	// DeclarativeDir is the canonical location for pg-delta declarative schema
 	// files generated or synced by `supabase db schema declarative` commands.
@@ -109,6 +116,8 @@ var (
 	FallbackEnvFilePath   = filepath.Join(FunctionsDir, ".env")
 	DbTestsDir            = filepath.Join(SupabaseDirPath, "tests")
 	CustomRolesPath       = filepath.Join(SupabaseDirPath, "roles.sql")
+	CustomLintsDir        = filepath.Join(SupabaseDirPath, "lints")
+	AdvisorsBaselinePath  = filepath.Join(SupabaseDirPath, "advisors-baseline.json")
 
 	ErrNotLinked   = errors.Errorf("Cannot find project ref. Have you run %s?", Aqua("supabase link"))
 	ErrInvalidRef  = errors.New("Invalid project ref format. Must be like `abcdefghijklmnopqrst`.")
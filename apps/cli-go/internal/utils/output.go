@@ -25,6 +25,8 @@ const (
 
 	// OutputMetadata is used with certain SSO commands only.
 	OutputMetadata = "metadata"
+	// OutputSarif is used with lint and advisor commands only.
+	OutputSarif = "sarif"
 )
 
 var OutputFormat = EnumFlag{
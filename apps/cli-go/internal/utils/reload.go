@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/go-errors/errors"
+)
+
+// ConfigHashLabel records the hash of the config used to create a container,
+// so that reload can tell which containers are out of date.
+const ConfigHashLabel = "com.supabase.cli.config-hash"
+
+// ReloadPlan collects the containers that would be created, recreated, or left
+// untouched when applying config to a running stack.
+type ReloadPlan struct {
+	// Apply recreates changed containers instead of only recording them.
+	Apply     bool
+	Unchanged []string
+	Changed   []string
+	Created   []string
+}
+
+// Services returns the ids of all containers visited by the plan.
+func (p *ReloadPlan) Services() []string {
+	return slices.Concat(p.Unchanged, p.Changed, p.Created)
+}
+
+type reloadPlanContextKey struct{}
+
+func WithReloadPlan(ctx context.Context, plan *ReloadPlan) context.Context {
+	return context.WithValue(ctx, reloadPlanContextKey{}, plan)
+}
+
+func ReloadPlanFromContext(ctx context.Context) *ReloadPlan {
+	plan, _ := ctx.Value(reloadPlanContextKey{}).(*ReloadPlan)
+	return plan
+}
+
+// IsReloadDryRun returns true if containers should not be started or removed.
+func IsReloadDryRun(ctx context.Context) bool {
+	plan := ReloadPlanFromContext(ctx)
+	return plan != nil && !plan.Apply
+}
+
+func hashContainerConfig(config container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig) (string, error) {
+	// Normalise list ordering that does not affect the created container
+	config.Env = slices.Sorted(slices.Values(config.Env))
+	hostConfig.Binds = slices.Sorted(slices.Values(hostConfig.Binds))
+	data, err := json.Marshal(struct {
+		Config           container.Config
+		HostConfig       container.HostConfig
+		NetworkingConfig network.NetworkingConfig
+	}{config, hostConfig, networkingConfig})
+	if err != nil {
+		return "", errors.Errorf("failed to hash container config: %w", err)
+	}
+	// ECDSA signatures are randomised, so API keys signed with asymmetric
+	// keys differ on every load even though their claims do not.
+	digest := sha256.Sum256([]byte(apiKeySignatures().Replace(string(data))))
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// apiKeySignatures strips the signature from generated API keys.
+func apiKeySignatures() *strings.Replacer {
+	var oldnew []string
+	for _, key := range []string{Config.Auth.AnonKey.Value, Config.Auth.ServiceRoleKey.Value} {
+		if i := strings.LastIndexByte(key, '.'); i > 0 {
+			oldnew = append(oldnew, key, key[:i])
+		}
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// reconcileContainer labels the container config with its hash and, when a
+// reload plan is in progress, decides whether the container needs to be
+// created. Returns true if container creation should be skipped.
+func reconcileContainer(ctx context.Context, config *container.Config, hostConfig container.HostConfig, networkingConfig network.NetworkingConfig, containerName string) (bool, error) {
+	hash, err := hashContainerConfig(*config, hostConfig, networkingConfig)
+	if err != nil {
+		return false, err
+	}
+	// Copy labels to avoid mutating the caller's map
+	labels := make(map[string]string, len(config.Labels)+1)
+	maps.Copy(labels, config.Labels)
+	labels[ConfigHashLabel] = hash
+	config.Labels = labels
+	plan := ReloadPlanFromContext(ctx)
+	if plan == nil {
+		return false, nil
+	}
+	resp, err := Docker.ContainerInspect(ctx, containerName)
+	if errdefs.IsNotFound(err) {
+		plan.Created = append(plan.Created, containerName)
+		return !plan.Apply, nil
+	} else if err != nil {
+		return false, errors.Errorf("failed to inspect container: %w", err)
+	}
+	if resp.Config != nil && resp.Config.Labels[ConfigHashLabel] == hash &&
+		resp.State != nil && resp.State.Running {
+		plan.Unchanged = append(plan.Unchanged, containerName)
+		return true, nil
+	}
+	plan.Changed = append(plan.Changed, containerName)
+	if !plan.Apply {
+		return true, nil
+	}
+	// Named volumes are preserved so that data survives the recreate
+	if err := Docker.ContainerRemove(ctx, containerName, container.RemoveOptions{Force: true}); err != nil {
+		return false, errors.Errorf("failed to remove container: %w", err)
+	}
+	return false, nil
+}
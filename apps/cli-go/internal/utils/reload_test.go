@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+)
+
+func TestReconcileContainer(t *testing.T) {
+	config := container.Config{
+		Image: imageId,
+		Env:   []string{"B=2", "A=1"},
+	}
+	hash, err := hashContainerConfig(config, container.HostConfig{}, network.NetworkingConfig{})
+	require.NoError(t, err)
+
+	mockInspect := func(labels map[string]string, running bool) {
+		gock.New(Docker.DaemonHost()).
+			Get("/v" + Docker.ClientVersion() + "/containers/" + containerId + "/json").
+			Reply(http.StatusOK).
+			JSON(container.InspectResponse{
+				ContainerJSONBase: &container.ContainerJSONBase{
+					State: &container.State{Running: running},
+				},
+				Config: &container.Config{Labels: labels},
+			})
+	}
+
+	t.Run("labels config without plan", func(t *testing.T) {
+		input := config
+		// Run test
+		skip, err := reconcileContainer(context.Background(), &input, container.HostConfig{}, network.NetworkingConfig{}, containerId)
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, skip)
+		assert.Equal(t, hash, input.Labels[ConfigHashLabel])
+		assert.Nil(t, config.Labels)
+	})
+
+	t.Run("ignores env ordering", func(t *testing.T) {
+		input := config
+		input.Env = []string{"A=1", "B=2"}
+		// Run test
+		actual, err := hashContainerConfig(input, container.HostConfig{}, network.NetworkingConfig{})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, hash, actual)
+	})
+
+	t.Run("skips unchanged container", func(t *testing.T) {
+		plan := ReloadPlan{Apply: true}
+		ctx := WithReloadPlan(context.Background(), &plan)
+		input := config
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		mockInspect(map[string]string{ConfigHashLabel: hash}, true)
+		// Run test
+		skip, err := reconcileContainer(ctx, &input, container.HostConfig{}, network.NetworkingConfig{}, containerId)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, skip)
+		assert.Equal(t, []string{containerId}, plan.Unchanged)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("plans changed container on dry run", func(t *testing.T) {
+		plan := ReloadPlan{}
+		ctx := WithReloadPlan(context.Background(), &plan)
+		input := config
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		mockInspect(map[string]string{ConfigHashLabel: "stale"}, true)
+		// Run test
+		skip, err := reconcileContainer(ctx, &input, container.HostConfig{}, network.NetworkingConfig{}, containerId)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, skip)
+		assert.Equal(t, []string{containerId}, plan.Changed)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("plans missing container on dry run", func(t *testing.T) {
+		plan := ReloadPlan{}
+		ctx := WithReloadPlan(context.Background(), &plan)
+		input := config
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		gock.New(Docker.DaemonHost()).
+			Get("/v" + Docker.ClientVersion() + "/containers/" + containerId + "/json").
+			Reply(http.StatusNotFound)
+		// Run test
+		skip, err := reconcileContainer(ctx, &input, container.HostConfig{}, network.NetworkingConfig{}, containerId)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, skip)
+		assert.Equal(t, []string{containerId}, plan.Created)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("removes stopped container on apply", func(t *testing.T) {
+		plan := ReloadPlan{Apply: true}
+		ctx := WithReloadPlan(context.Background(), &plan)
+		input := config
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		mockInspect(map[string]string{ConfigHashLabel: hash}, false)
+		gock.New(Docker.DaemonHost()).
+			Delete("/v" + Docker.ClientVersion() + "/containers/" + containerId).
+			Reply(http.StatusOK)
+		// Run test
+		skip, err := reconcileContainer(ctx, &input, container.HostConfig{}, network.NetworkingConfig{}, containerId)
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, skip)
+		assert.Equal(t, []string{containerId}, plan.Changed)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on failure to remove", func(t *testing.T) {
+		plan := ReloadPlan{Apply: true}
+		ctx := WithReloadPlan(context.Background(), &plan)
+		input := config
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(Docker))
+		defer gock.OffAll()
+		mockInspect(nil, true)
+		gock.New(Docker.DaemonHost()).
+			Delete("/v" + Docker.ClientVersion() + "/containers/" + containerId).
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := reconcileContainer(ctx, &input, container.HostConfig{}, network.NetworkingConfig{}, containerId)
+		// Check error
+		assert.ErrorContains(t, err, "failed to remove container:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
@@ -0,0 +1,150 @@
+package sarif
+
+import (
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+type sourceFile struct {
+	path     string
+	contents string
+}
+
+// Locator resolves database objects to the local file that defines them.
+// Declarative schema files take precedence over migrations because they
+// describe the current state of each object.
+type Locator struct {
+	schemas    []sourceFile
+	migrations []sourceFile
+}
+
+func NewLocator(fsys afero.Fs) (*Locator, error) {
+	var l Locator
+	for _, dir := range utils.RemoveDuplicates([]string{utils.GetDeclarativeDir(), utils.SchemasDir}) {
+		paths, err := walkSQLFiles(fsys, dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, fp := range paths {
+			f, err := readSourceFile(fsys, fp)
+			if err != nil {
+				return nil, err
+			}
+			l.schemas = append(l.schemas, f)
+		}
+	}
+	paths, err := migration.ListLocalMigrations(utils.MigrationsDir, afero.NewIOFS(fsys))
+	if err != nil {
+		return nil, err
+	}
+	for _, fp := range paths {
+		f, err := readSourceFile(fsys, fp)
+		if err != nil {
+			return nil, err
+		}
+		l.migrations = append(l.migrations, f)
+	}
+	return &l, nil
+}
+
+func walkSQLFiles(fsys afero.Fs, dir string) ([]string, error) {
+	if exists, err := afero.DirExists(fsys, dir); err != nil {
+		return nil, errors.Errorf("failed to check schemas: %w", err)
+	} else if !exists {
+		return nil, nil
+	}
+	var paths []string
+	if err := afero.Walk(fsys, dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() && filepath.Ext(info.Name()) == ".sql" {
+			paths = append(paths, path)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Errorf("failed to walk dir: %w", err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func readSourceFile(fsys afero.Fs, path string) (sourceFile, error) {
+	contents, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return sourceFile{}, errors.Errorf("failed to read file: %w", err)
+	}
+	return sourceFile{path: path, contents: string(contents)}, nil
+}
+
+// Find returns the location of the create statement for schema.name. Objects
+// in the public schema may be declared without a schema qualifier.
+func (l *Locator) Find(schema, name string) *Location {
+	if l == nil || len(name) == 0 {
+		return nil
+	}
+	pattern := objectPattern(schema, name)
+	for _, f := range l.schemas {
+		if line := findLine(pattern, f.contents); line > 0 {
+			return NewLocation(f.path, line)
+		}
+	}
+	// The latest migration holds the current definition of replaced objects
+	for i := len(l.migrations) - 1; i >= 0; i-- {
+		f := l.migrations[i]
+		if line := findLine(pattern, f.contents); line > 0 {
+			return NewLocation(f.path, line)
+		}
+	}
+	return nil
+}
+
+// FindQualified resolves a dot separated identifier, ie. schema.name.
+func (l *Locator) FindQualified(identifier string) *Location {
+	schema, name, found := strings.Cut(identifier, ".")
+	if !found {
+		return l.Find("public", identifier)
+	}
+	return l.Find(schema, name)
+}
+
+func objectPattern(schema, name string) *regexp.Regexp {
+	qualifier := `"?` + regexp.QuoteMeta(schema) + `"?\s*\.\s*`
+	if len(schema) == 0 || schema == "public" {
+		qualifier = "(?:" + qualifier + ")?"
+	}
+	return regexp.MustCompile(`(?i)\bcreate\s+(?:or\s+replace\s+)?(?:[a-z_]+\s+){0,3}?(?:if\s+not\s+exists\s+)?` +
+		qualifier + `"?` + regexp.QuoteMeta(name) + `"?(?:[\s(;]|$)`)
+}
+
+func findLine(pattern *regexp.Regexp, contents string) int {
+	loc := pattern.FindStringIndex(contents)
+	if loc == nil {
+		return 0
+	}
+	return strings.Count(contents[:loc[0]], "\n") + 1
+}
+
+// OrDefault falls back to the project config for objects that are not defined
+// in any local file, since code scanning rejects results without a location.
+func OrDefault(location *Location) Location {
+	if location != nil {
+		return *location
+	}
+	return *NewLocation(utils.ConfigPath, 1)
+}
+
+func NewLocation(path string, line int) *Location {
+	return &Location{PhysicalLocation: PhysicalLocation{
+		ArtifactLocation: ArtifactLocation{URI: filepath.ToSlash(path)},
+		Region:           &Region{StartLine: line},
+	}}
+}
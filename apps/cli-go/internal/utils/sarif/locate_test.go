@@ -0,0 +1,65 @@
+package sarif
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestLocator(t *testing.T) {
+	fsys := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "20240101000000_init.sql"), []byte(`create table todos (id bigint);
+create function private.f1() returns void language sql as $$ select 1 $$;
+`), 0644))
+	require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "20240102000000_update.sql"), []byte(`
+CREATE OR REPLACE FUNCTION "private"."f1"() RETURNS void LANGUAGE sql AS $$ select 2 $$;
+`), 0644))
+	require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.SchemasDir, "profiles.sql"), []byte(`-- profiles
+create table if not exists public.profiles (id uuid);
+`), 0644))
+	locator, err := NewLocator(fsys)
+	require.NoError(t, err)
+
+	t.Run("finds unqualified object in public schema", func(t *testing.T) {
+		loc := locator.Find("public", "todos")
+		require.NotNil(t, loc)
+		assert.Equal(t, "supabase/migrations/20240101000000_init.sql", loc.PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 1, loc.PhysicalLocation.Region.StartLine)
+	})
+
+	t.Run("prefers latest migration", func(t *testing.T) {
+		loc := locator.FindQualified("private.f1")
+		require.NotNil(t, loc)
+		assert.Equal(t, "supabase/migrations/20240102000000_update.sql", loc.PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 2, loc.PhysicalLocation.Region.StartLine)
+	})
+
+	t.Run("prefers declarative schema", func(t *testing.T) {
+		loc := locator.Find("public", "profiles")
+		require.NotNil(t, loc)
+		assert.Equal(t, "supabase/schemas/profiles.sql", loc.PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 2, loc.PhysicalLocation.Region.StartLine)
+	})
+
+	t.Run("ignores unknown object", func(t *testing.T) {
+		assert.Nil(t, locator.Find("private", "todos"))
+		assert.Nil(t, locator.Find("public", "todo"))
+	})
+
+	t.Run("falls back to config for unknown object", func(t *testing.T) {
+		loc := OrDefault(locator.Find("private", "todos"))
+		assert.Equal(t, "supabase/config.toml", loc.PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 1, loc.PhysicalLocation.Region.StartLine)
+	})
+}
+
+func TestToLevel(t *testing.T) {
+	assert.Equal(t, "error", ToLevel("ERROR"))
+	assert.Equal(t, "warning", ToLevel("WARN"))
+	assert.Equal(t, "warning", ToLevel("warning"))
+	assert.Equal(t, "note", ToLevel("INFO"))
+}
@@ -0,0 +1,142 @@
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	Version   = "2.1.0"
+	SchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Log is the minimal subset of the SARIF 2.1.0 schema understood by GitHub code scanning.
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+type Rule struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name,omitempty"`
+	ShortDescription *Message `json:"shortDescription,omitempty"`
+	FullDescription  *Message `json:"fullDescription,omitempty"`
+	HelpURI          string   `json:"helpUri,omitempty"`
+	Help             *Message `json:"help,omitempty"`
+}
+
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             Message           `json:"message"`
+	Locations           []Location        `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type Region struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// NewLog creates a log with a single run for the named tool. Rules are
+// collected from results as they are added.
+func NewLog(name string) *Log {
+	return &Log{
+		Schema:  SchemaURI,
+		Version: Version,
+		Runs: []Run{{
+			Tool: Tool{Driver: Driver{
+				Name:           name,
+				Version:        utils.Version,
+				InformationURI: "https://supabase.com/docs/reference/cli",
+				Rules:          []Rule{},
+			}},
+			Results: []Result{},
+		}},
+	}
+}
+
+// AddRule registers a rule once, keeping the first description seen.
+func (l *Log) AddRule(rule Rule) {
+	driver := &l.Runs[0].Tool.Driver
+	for _, r := range driver.Rules {
+		if r.ID == rule.ID {
+			return
+		}
+	}
+	driver.Rules = append(driver.Rules, rule)
+}
+
+func (l *Log) AddResult(result Result) {
+	l.Runs[0].Results = append(l.Runs[0].Results, result)
+}
+
+func (l *Log) Write(w io.Writer) error {
+	driver := &l.Runs[0].Tool.Driver
+	sort.SliceStable(driver.Rules, func(i, j int) bool {
+		return driver.Rules[i].ID < driver.Rules[j].ID
+	})
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(l); err != nil {
+		return errors.Errorf("failed to print sarif: %w", err)
+	}
+	return nil
+}
+
+// ToLevel maps advisor and lint levels to SARIF result levels.
+func ToLevel(level string) string {
+	switch strings.ToLower(level) {
+	case "error":
+		return "error"
+	case "warn", "warning":
+		return "warning"
+	}
+	return "note"
+}
+
+func NewMessage(text string) *Message {
+	if len(text) == 0 {
+		return nil
+	}
+	return &Message{Text: text}
+}
@@ -0,0 +1,103 @@
+package pin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/versions"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// Run writes service versions from source into the version lockfile. When
+// merging, services without a version from source keep their pinned version.
+// Otherwise, they are pinned to their local version.
+func Run(ctx context.Context, source string, merge bool, fsys afero.Fs) error {
+	if source == versions.SourceLinked {
+		if err := flags.LoadProjectRef(fsys); err != nil {
+			return err
+		}
+	}
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	result, err := versions.ListVersions(ctx, fsys)
+	if err != nil {
+		return err
+	}
+	if source == versions.SourceLinked && !hasLinked(result) {
+		return errors.Errorf("failed to get service versions of linked project: %s", flags.ProjectRef)
+	}
+	if source == versions.SourceLatest {
+		versions.LookupLatest(ctx, result)
+		if !hasLatest(result) {
+			return errors.Errorf("failed to get latest service versions from registry: %s", utils.GetRegistry())
+		}
+	}
+	lock := config.VersionLock{}
+	if merge {
+		if lock, err = config.LoadVersionLock(utils.VersionLockPath, afero.NewIOFS(fsys)); err != nil {
+			return err
+		}
+	}
+	changed := UpdateLock(lock, result, source, merge)
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to marshal version lock: %w", err)
+	}
+	if err := utils.WriteFile(utils.VersionLockPath, append(data, '\n'), fsys); err != nil {
+		return err
+	}
+	for _, line := range changed {
+		fmt.Fprintln(os.Stderr, line)
+	}
+	fmt.Fprintln(os.Stderr, "Pinned service versions in "+utils.Bold(utils.VersionLockPath))
+	if len(changed) > 0 {
+		fmt.Fprintf(os.Stderr, "Run %s to apply them.\n", utils.Aqua("supabase reload"))
+	}
+	return nil
+}
+
+// UpdateLock pins the versions from source and describes the changed entries.
+func UpdateLock(lock config.VersionLock, result []versions.ServiceVersion, source string, merge bool) []string {
+	var changed []string
+	for _, v := range result {
+		tag := v.Get(source)
+		if len(tag) == 0 {
+			if merge {
+				continue
+			}
+			tag = v.Local
+		}
+		if prev, ok := lock[v.Name]; !ok {
+			changed = append(changed, fmt.Sprintf("%s: %s", v.Name, tag))
+		} else if prev != tag {
+			changed = append(changed, fmt.Sprintf("%s: %s => %s", v.Name, prev, tag))
+		}
+		lock[v.Name] = tag
+	}
+	return changed
+}
+
+func hasLinked(result []versions.ServiceVersion) bool {
+	for _, v := range result {
+		if len(v.Linked) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func hasLatest(result []versions.ServiceVersion) bool {
+	for _, v := range result {
+		if len(v.Latest) > 0 {
+			return true
+		}
+	}
+	return false
+}
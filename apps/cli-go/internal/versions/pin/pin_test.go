@@ -0,0 +1,90 @@
+package pin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/internal/versions"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestUpdateLock(t *testing.T) {
+	result := []versions.ServiceVersion{
+		{Name: "postgres", Local: "17.4.1.054", Linked: "17.4.1.060", Bundled: "17.4.1.060"},
+		{Name: "gotrue", Local: "v2.170.0", Bundled: "v2.177.0"},
+	}
+
+	t.Run("pins local version without linked version", func(t *testing.T) {
+		lock := config.VersionLock{}
+		// Run test
+		changed := UpdateLock(lock, result, versions.SourceLinked, false)
+		// Check output
+		assert.Equal(t, config.VersionLock{"postgres": "17.4.1.060", "gotrue": "v2.170.0"}, lock)
+		assert.Equal(t, []string{"postgres: 17.4.1.060", "gotrue: v2.170.0"}, changed)
+	})
+
+	t.Run("keeps pinned version without linked version", func(t *testing.T) {
+		lock := config.VersionLock{"postgres": "17.4.1.054", "gotrue": "v2.160.0"}
+		// Run test
+		changed := UpdateLock(lock, result, versions.SourceLinked, true)
+		// Check output
+		assert.Equal(t, config.VersionLock{"postgres": "17.4.1.060", "gotrue": "v2.160.0"}, lock)
+		assert.Equal(t, []string{"postgres: 17.4.1.054 => 17.4.1.060"}, changed)
+	})
+}
+
+func TestPinVersions(t *testing.T) {
+	t.Run("pins bundled versions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), versions.SourceBundled, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, utils.VersionLockPath)
+		require.NoError(t, err)
+		var lock config.VersionLock
+		require.NoError(t, json.Unmarshal(data, &lock))
+		assert.Equal(t, "supabase/gotrue:"+lock["gotrue"], config.Images.Gotrue)
+		assert.Len(t, lock, len(utils.Config.GetServiceImages()))
+	})
+
+	t.Run("throws error on unreachable registry", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Setup mock registry
+		defer gock.OffAll()
+		gock.New("https://public.ecr.aws").
+			Get("/v2/").
+			Persist().
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), versions.SourceLatest, true, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to get latest service versions from registry: public.ecr.aws")
+		exists, err := afero.Exists(fsys, utils.VersionLockPath)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("throws error on unlinked project", func(t *testing.T) {
+		flags.ProjectRef = ""
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), versions.SourceLinked, true, fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrNotLinked)
+	})
+}
@@ -0,0 +1,213 @@
+package versions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/fetcher"
+	"github.com/supabase/cli/pkg/queue"
+)
+
+var (
+	// Matches an optional prefix, dot separated numbers and a suffix, ie. v2.170.0-rc1
+	tagPattern = regexp.MustCompile(`^([a-z-]*)(\d+(?:\.\d+)*)(.*)$`)
+	// Matches commit hashes embedded in tags, ie. 2025.06.02-sha-8f2993d
+	commitHash = regexp.MustCompile(`[0-9a-f]{7,40}`)
+	// Matches key value pairs in the WWW-Authenticate header
+	authParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// LookupLatest sets the latest registry tag of each service. Services whose
+// tags cannot be listed are left without a latest version.
+func LookupLatest(ctx context.Context, result []ServiceVersion) {
+	jq := queue.NewJobQueue(5)
+	logger := utils.GetDebugLogger()
+	for i := range result {
+		v := &result[i]
+		job := func() error {
+			tags, err := ListRegistryTags(ctx, v.image)
+			if err != nil {
+				return err
+			}
+			v.Latest = LatestTag(v.Local, tags, v.Name == "postgres")
+			return nil
+		}
+		if err := jq.Put(job); err != nil {
+			fmt.Fprintln(logger, err)
+		}
+	}
+	if err := jq.Collect(); err != nil {
+		fmt.Fprintln(logger, err)
+	}
+}
+
+type tagList struct {
+	Tags []string `json:"tags"`
+}
+
+type tokenResponse struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+}
+
+// ListRegistryTags returns all tags of an image from the registry that service
+// images are pulled from.
+func ListRegistryTags(ctx context.Context, image string) ([]string, error) {
+	host, repo := registryRepo(image)
+	api := fetcher.NewFetcher(
+		"https://"+host,
+		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
+		fetcher.WithExpectedStatus(http.StatusOK, http.StatusUnauthorized),
+	)
+	var token string
+	var tags []string
+	next := fmt.Sprintf("/v2/%s/tags/list?n=1000", repo)
+	for len(next) > 0 {
+		resp, err := api.Send(ctx, http.MethodGet, next, nil, func(req *http.Request) {
+			if len(token) > 0 {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		})
+		if err != nil {
+			return nil, errors.Errorf("failed to list tags of %s: %w", image, err)
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			resp.Body.Close()
+			if len(token) > 0 {
+				return nil, errors.Errorf("failed to list tags of %s: %s", image, resp.Status)
+			}
+			if token, err = getAnonymousToken(ctx, resp.Header.Get("WWW-Authenticate"), repo); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		list, err := fetcher.ParseJSON[tagList](resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, list.Tags...)
+		next = nextPage(resp.Header.Get("Link"))
+	}
+	return tags, nil
+}
+
+// registryRepo returns the registry host and repository of an image without tag.
+func registryRepo(image string) (string, string) {
+	name, _, _ := strings.Cut(utils.GetRegistryImageUrl(image), ":")
+	host, repo, found := strings.Cut(name, "/")
+	if found && strings.ContainsAny(host, ".:") {
+		return host, repo
+	}
+	// Images without registry host are pulled from docker hub
+	if !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	return "registry-1.docker.io", name
+}
+
+// getAnonymousToken requests a pull token from the realm in the bearer challenge.
+func getAnonymousToken(ctx context.Context, challenge, repo string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", errors.Errorf("unsupported registry auth challenge: %s", challenge)
+	}
+	query := url.Values{"scope": {"repository:" + repo + ":pull"}}
+	var realm string
+	for _, m := range authParam.FindAllStringSubmatch(params, -1) {
+		if m[1] == "realm" {
+			realm = m[2]
+		} else {
+			query.Set(m[1], m[2])
+		}
+	}
+	if len(realm) == 0 {
+		return "", errors.Errorf("missing realm in registry auth challenge: %s", challenge)
+	}
+	api := fetcher.NewFetcher(
+		realm,
+		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
+		fetcher.WithExpectedStatus(http.StatusOK),
+	)
+	resp, err := api.Send(ctx, http.MethodGet, "?"+query.Encode(), nil)
+	if err != nil {
+		return "", errors.Errorf("failed to get registry token: %w", err)
+	}
+	body, err := fetcher.ParseJSON[tokenResponse](resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if len(body.Token) > 0 {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// nextPage returns the request URI of the next page from a Link header.
+func nextPage(link string) string {
+	target, rel, _ := strings.Cut(link, ";")
+	if !strings.Contains(rel, `rel="next"`) {
+		return ""
+	}
+	parsed, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+	if err != nil {
+		return ""
+	}
+	return parsed.RequestURI()
+}
+
+// LatestTag returns the greatest tag with the same format as current, ie. same
+// prefix, number of components and suffix. When sameMajor is set, only tags with
+// the same major version are considered. Returns empty if current is unversioned.
+func LatestTag(current string, tags []string, sameMajor bool) string {
+	prefix, version, suffix, ok := parseTag(current)
+	if !ok {
+		return ""
+	}
+	latest := current
+	for _, tag := range tags {
+		p, v, s, ok := parseTag(tag)
+		if !ok || p != prefix || s != suffix || len(v) != len(version) {
+			continue
+		}
+		if sameMajor && v[0] != version[0] {
+			continue
+		}
+		if compareVersion(v, version) > 0 {
+			latest, version = tag, v
+		}
+	}
+	return latest
+}
+
+func parseTag(tag string) (string, []int, string, bool) {
+	m := tagPattern.FindStringSubmatch(tag)
+	if m == nil {
+		return "", nil, "", false
+	}
+	parts := strings.Split(m[2], ".")
+	version := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return "", nil, "", false
+		}
+		version[i] = n
+	}
+	return m[1], version, commitHash.ReplaceAllString(m[3], "*"), true
+}
+
+func compareVersion(a, b []int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
@@ -0,0 +1,100 @@
+package versions
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+)
+
+func TestListRegistryTags(t *testing.T) {
+	t.Run("lists tags with anonymous token", func(t *testing.T) {
+		// Setup mock registry
+		defer gock.OffAll()
+		gock.New("https://public.ecr.aws").
+			Get("/v2/supabase/gotrue/tags/list").
+			Reply(http.StatusUnauthorized).
+			SetHeader("WWW-Authenticate", `Bearer realm="https://public.ecr.aws/token/",service="public.ecr.aws",scope="aws"`)
+		gock.New("https://public.ecr.aws").
+			Get("/token/").
+			MatchParam("service", "public.ecr.aws").
+			MatchParam("scope", "aws").
+			Reply(http.StatusOK).
+			JSON(tokenResponse{Token: "test-token"})
+		gock.New("https://public.ecr.aws").
+			Get("/v2/supabase/gotrue/tags/list").
+			MatchHeader("Authorization", "Bearer test-token").
+			Reply(http.StatusOK).
+			SetHeader("Link", `</v2/supabase/gotrue/tags/list?last=v2.170.0&n=1000>; rel="next"`).
+			JSON(tagList{Tags: []string{"v2.170.0"}})
+		gock.New("https://public.ecr.aws").
+			Get("/v2/supabase/gotrue/tags/list").
+			MatchParam("last", "v2.170.0").
+			MatchHeader("Authorization", "Bearer test-token").
+			Reply(http.StatusOK).
+			JSON(tagList{Tags: []string{"v2.177.0"}})
+		// Run test
+		tags, err := ListRegistryTags(context.Background(), "supabase/gotrue:v2.170.0")
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"v2.170.0", "v2.177.0"}, tags)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on rejected token", func(t *testing.T) {
+		// Setup mock registry
+		defer gock.OffAll()
+		gock.New("https://public.ecr.aws").
+			Get("/v2/supabase/gotrue/tags/list").
+			Times(2).
+			Reply(http.StatusUnauthorized).
+			SetHeader("WWW-Authenticate", `Bearer realm="https://public.ecr.aws/token/",service="public.ecr.aws"`)
+		gock.New("https://public.ecr.aws").
+			Get("/token/").
+			Reply(http.StatusOK).
+			JSON(tokenResponse{AccessToken: "test-token"})
+		// Run test
+		_, err := ListRegistryTags(context.Background(), "supabase/gotrue:v2.170.0")
+		// Check error
+		assert.ErrorContains(t, err, "failed to list tags of supabase/gotrue:v2.170.0: 401 Unauthorized")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestLatestTag(t *testing.T) {
+	t.Run("picks greatest tag of same format", func(t *testing.T) {
+		tags := []string{"latest", "v2.99.0", "v2.177.0", "v2.180.0-rc1", "v2.178", "2.190.0"}
+		assert.Equal(t, "v2.177.0", LatestTag("v2.170.0", tags, false))
+	})
+
+	t.Run("picks postgres tag of same major version", func(t *testing.T) {
+		tags := []string{"15.8.1.085", "17.4.1.060", "17.4.1.054", "17.4.1.054-orioledb"}
+		assert.Equal(t, "15.8.1.085", LatestTag("15.8.1.044", tags, true))
+		assert.Equal(t, "17.4.1.060", LatestTag("17.4.1.054", tags, true))
+	})
+
+	t.Run("ignores commit hash in suffix", func(t *testing.T) {
+		tags := []string{"2025.06.02-sha-8f2993d", "2026.05.25-sha-65c570e"}
+		assert.Equal(t, "2026.05.25-sha-65c570e", LatestTag("2025.06.02-sha-8f2993d", tags, false))
+	})
+
+	t.Run("keeps current tag without newer release", func(t *testing.T) {
+		assert.Equal(t, "v1.0.0", LatestTag("v1.0.0", []string{"v0.9.0"}, false))
+	})
+
+	t.Run("skips unversioned tag", func(t *testing.T) {
+		assert.Empty(t, LatestTag("latest", []string{"v1.0.0"}, false))
+	})
+}
+
+func TestOutdated(t *testing.T) {
+	v := ServiceVersion{Local: "v2.170.0", Latest: "v2.177.0", Bundled: "v2.170.0"}
+	assert.True(t, v.Outdated())
+	v.Linked = "v2.170.0"
+	assert.False(t, v.Outdated())
+	v.Linked, v.Latest = "", ""
+	assert.False(t, v.Outdated())
+}
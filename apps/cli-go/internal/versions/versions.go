@@ -0,0 +1,142 @@
+package versions
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/services"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
+)
+
+const (
+	SourceLocal   = "local"
+	SourceLinked  = "linked"
+	SourceLatest  = "latest"
+	SourceBundled = "bundled"
+)
+
+type ServiceVersion struct {
+	Name    string `json:"name"`
+	Local   string `json:"local"`
+	Linked  string `json:"linked,omitempty"`
+	Latest  string `json:"latest,omitempty"`
+	Bundled string `json:"bundled"`
+	Pinned  bool   `json:"pinned"`
+	image   string
+}
+
+// Outdated reports whether the local version differs from the linked project,
+// or from the latest registry tag if not linked. Falls back to the version
+// bundled with the CLI if the registry is unreachable.
+func (v ServiceVersion) Outdated() bool {
+	if len(v.Linked) > 0 {
+		return v.Local != v.Linked
+	}
+	if len(v.Latest) > 0 {
+		return v.Local != v.Latest
+	}
+	return v.Local != v.Bundled
+}
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	if err := flags.LoadProjectRef(fsys); err != nil && !errors.Is(err, utils.ErrNotLinked) {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if err := flags.LoadConfig(fsys); err != nil {
+		return err
+	}
+	result, err := ListVersions(ctx, fsys)
+	if err != nil {
+		return err
+	}
+	LookupLatest(ctx, result)
+	if utils.OutputFormat.Value != utils.OutputPretty {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, result)
+	}
+	var table strings.Builder
+	table.WriteString(`|SERVICE|LOCAL|LINKED|LATEST|BUNDLED|
+|-|-|-|-|-|
+`)
+	var outdated []string
+	for _, v := range result {
+		local := v.Local
+		if v.Pinned {
+			local += " (pinned)"
+		}
+		fmt.Fprintf(&table, "|`%s`|`%s`|`%s`|`%s`|`%s`|\n", v.Name, local, orDash(v.Linked), orDash(v.Latest), v.Bundled)
+		if v.Outdated() {
+			outdated = append(outdated, v.Name)
+		}
+	}
+	if err := utils.RenderTable(table.String()); err != nil {
+		return err
+	}
+	if len(outdated) > 0 {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Some services are out of date:", strings.Join(outdated, ", "))
+		source := SourceLatest
+		if len(flags.ProjectRef) > 0 {
+			source = SourceLinked
+		}
+		fmt.Fprintf(os.Stderr, "Run %s to pin them for your team.\n", utils.Aqua("supabase versions sync --from "+source))
+	}
+	return nil
+}
+
+// ListVersions returns the local, linked and bundled versions of each service.
+// Use LookupLatest to also fill in the latest registry tags.
+func ListVersions(ctx context.Context, fsys afero.Fs) ([]ServiceVersion, error) {
+	lock, err := config.LoadVersionLock(utils.VersionLockPath, afero.NewIOFS(fsys))
+	if err != nil {
+		return nil, err
+	}
+	var remote map[string]string
+	if _, err := utils.LoadAccessTokenFS(fsys); err == nil && len(flags.ProjectRef) > 0 {
+		remote = services.ListRemoteImages(ctx, flags.ProjectRef)
+	}
+	local := utils.Config.GetServiceImages()
+	bundled := utils.Config.GetBundledServiceImages()
+	result := make([]ServiceVersion, len(local))
+	for i, image := range local {
+		name := config.ImageName(image)
+		_, pinned := lock[name]
+		result[i] = ServiceVersion{
+			Name:    name,
+			Local:   imageTag(image),
+			Linked:  remote[image],
+			Bundled: imageTag(bundled[i]),
+			Pinned:  pinned,
+			image:   image,
+		}
+	}
+	return result, nil
+}
+
+// Get returns the version from the given source, or empty if unknown.
+func (v ServiceVersion) Get(source string) string {
+	switch source {
+	case SourceLinked:
+		return v.Linked
+	case SourceLatest:
+		return v.Latest
+	case SourceBundled:
+		return v.Bundled
+	}
+	return v.Local
+}
+
+func orDash(tag string) string {
+	if len(tag) == 0 {
+		return "-"
+	}
+	return tag
+}
+
+func imageTag(image string) string {
+	return image[strings.LastIndexByte(image, ':')+1:]
+}
@@ -0,0 +1,49 @@
+package versions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestListVersions(t *testing.T) {
+	flags.ProjectRef = ""
+
+	t.Run("shows pinned versions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		require.NoError(t, afero.WriteFile(fsys, utils.VersionLockPath, []byte(`{"gotrue": "v2.100.0"}`), 0644))
+		require.NoError(t, flags.LoadConfig(fsys))
+		// Run test
+		result, err := ListVersions(context.Background(), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, result, len(utils.Config.GetServiceImages()))
+		assert.Equal(t, ServiceVersion{
+			Name:    "gotrue",
+			Local:   "v2.100.0",
+			Bundled: config.Images.Gotrue[len("supabase/gotrue:"):],
+			Pinned:  true,
+			image:   utils.Config.Auth.Image,
+		}, result[1])
+		assert.True(t, result[1].Outdated())
+		assert.False(t, result[2].Outdated())
+	})
+
+	t.Run("throws error on malformed lockfile", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.VersionLockPath, []byte(`[]`), 0644))
+		// Run test
+		_, err := ListVersions(context.Background(), fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse version lock:")
+	})
+}
@@ -24,10 +24,11 @@ type (
 		// can flip the implicit value without losing the explicit user choice.
 		AutoExposeNewTables *bool `toml:"auto_expose_new_tables,omitempty" json:"auto_expose_new_tables,omitempty"`
 		// Local only config
-		Image     string  `toml:"-" json:"-"`
-		KongImage string  `toml:"-" json:"-"`
-		Port      uint16  `toml:"port" json:"port"`
-		Tls       tlsKong `toml:"tls" json:"tls"`
+		Image     string     `toml:"-" json:"-"`
+		KongImage string     `toml:"-" json:"-"`
+		Port      uint16     `toml:"port" json:"port"`
+		Tls       tlsKong    `toml:"tls" json:"tls"`
+		Resources *Resources `toml:"resources" json:"resources"`
 		// TODO: replace [auth|studio].api_url
 		ExternalUrl string `toml:"external_url" json:"external_url"`
 	}
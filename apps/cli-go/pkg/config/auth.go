@@ -146,8 +146,9 @@ func (j JWK) ToPublicJWK() JWK {
 
 type (
 	auth struct {
-		Enabled bool   `toml:"enabled" json:"enabled"`
-		Image   string `toml:"-" json:"-"`
+		Enabled   bool       `toml:"enabled" json:"enabled"`
+		Image     string     `toml:"-" json:"-"`
+		Resources *Resources `toml:"resources" json:"resources"`
 
 		SiteUrl                    string               `toml:"site_url" json:"site_url"`
 		ExternalUrl                string               `toml:"external_url" json:"external_url"`
@@ -285,6 +286,14 @@ type (
 		Vonage              vonageConfig      `toml:"vonage" json:"vonage"`
 		TestOTP             map[string]string `toml:"test_otp" json:"test_otp"`
 		MaxFrequency        time.Duration     `toml:"max_frequency" json:"max_frequency"`
+		Capture             smsCapture        `toml:"capture" json:"capture"`
+	}
+
+	// smsCapture routes local SMS through a send-SMS hook to a receiver
+	// container, so that phone auth can be tested without a provider.
+	smsCapture struct {
+		Enabled bool   `toml:"enabled" json:"enabled"`
+		Port    uint16 `toml:"port" json:"port"`
 	}
 
 	captcha struct {
@@ -151,6 +151,7 @@ type (
 		Functions    FunctionConfig `toml:"functions" json:"functions"`
 		Analytics    analytics      `toml:"analytics" json:"analytics"`
 		Experimental experimental   `toml:"experimental" json:"experimental"`
+		Local        local          `toml:"local" json:"local"`
 	}
 
 	config struct {
@@ -163,28 +164,31 @@ type (
 		Image           string        `toml:"-" json:"-"`
 		IpVersion       AddressFamily `toml:"ip_version" json:"ip_version"`
 		MaxHeaderLength uint          `toml:"max_header_length" json:"max_header_length"`
+		Resources       *Resources    `toml:"resources" json:"resources"`
 		TenantId        string        `toml:"-" json:"-"`
 		EncryptionKey   string        `toml:"-" json:"-"`
 		SecretKeyBase   string        `toml:"-" json:"-"`
 	}
 
 	studio struct {
-		Enabled      bool   `toml:"enabled" json:"enabled"`
-		Image        string `toml:"-" json:"-"`
-		Port         uint16 `toml:"port" json:"port"`
-		ApiUrl       string `toml:"api_url" json:"api_url"`
-		OpenaiApiKey Secret `toml:"openai_api_key" json:"openai_api_key"`
-		PgmetaImage  string `toml:"-" json:"-"`
+		Enabled      bool       `toml:"enabled" json:"enabled"`
+		Image        string     `toml:"-" json:"-"`
+		Port         uint16     `toml:"port" json:"port"`
+		ApiUrl       string     `toml:"api_url" json:"api_url"`
+		OpenaiApiKey Secret     `toml:"openai_api_key" json:"openai_api_key"`
+		PgmetaImage  string     `toml:"-" json:"-"`
+		Resources    *Resources `toml:"resources" json:"resources"`
 	}
 
 	inbucket struct {
-		Enabled    bool   `toml:"enabled" json:"enabled"`
-		Image      string `toml:"-" json:"-"`
-		Port       uint16 `toml:"port" json:"port"`
-		SmtpPort   uint16 `toml:"smtp_port" json:"smtp_port"`
-		Pop3Port   uint16 `toml:"pop3_port" json:"pop3_port"`
-		AdminEmail string `toml:"admin_email" json:"admin_email"`
-		SenderName string `toml:"sender_name" json:"sender_name"`
+		Enabled    bool       `toml:"enabled" json:"enabled"`
+		Image      string     `toml:"-" json:"-"`
+		Port       uint16     `toml:"port" json:"port"`
+		SmtpPort   uint16     `toml:"smtp_port" json:"smtp_port"`
+		Pop3Port   uint16     `toml:"pop3_port" json:"pop3_port"`
+		AdminEmail string     `toml:"admin_email" json:"admin_email"`
+		SenderName string     `toml:"sender_name" json:"sender_name"`
+		Resources  *Resources `toml:"resources" json:"resources"`
 	}
 
 	edgeRuntime struct {
@@ -194,6 +198,7 @@ type (
 		InspectorPort uint16        `toml:"inspector_port" json:"inspector_port"`
 		Secrets       SecretsConfig `toml:"secrets" json:"secrets"`
 		DenoVersion   uint          `toml:"deno_version" json:"deno_version"`
+		Resources     *Resources    `toml:"resources" json:"resources"`
 	}
 
 	SecretsConfig  map[string]Secret
@@ -216,6 +221,7 @@ type (
 		GcpProjectId     string          `toml:"gcp_project_id" json:"gcp_project_id"`
 		GcpProjectNumber string          `toml:"gcp_project_number" json:"gcp_project_number"`
 		GcpJwtPath       string          `toml:"gcp_jwt_path" json:"gcp_jwt_path"`
+		Resources        *Resources      `toml:"resources" json:"resources"`
 		ApiKey           string          `toml:"-" json:"-"`
 		// Deprecated together with syslog
 		VectorPort uint16 `toml:"vector_port" json:"vector_port"`
@@ -234,7 +240,8 @@ type (
 	}
 
 	inspect struct {
-		Rules []rule `toml:"rules" json:"rules"`
+		Rules     []rule `toml:"rules" json:"rules"`
+		DiffRules []rule `toml:"diff_rules" json:"diff_rules"`
 	}
 
 	rule struct {
@@ -511,7 +518,10 @@ func (c *config) loadFromFile(filename string, fsys fs.FS) error {
 			}
 		}
 	}
-	return c.load(v)
+	if err := c.load(v); err != nil {
+		return err
+	}
+	return c.Local.restoreEnvKeys(filename, fsys)
 }
 
 func (c *config) mergeDefaultValues(v *viper.Viper) error {
@@ -626,6 +636,18 @@ func (c *config) Load(path string, fsys fs.FS, overrides ...ConfigEditor) error
 	if connString, err := fs.ReadFile(fsys, builder.PoolerUrlPath); err == nil && len(connString) > 0 {
 		c.Db.Pooler.ConnectionString = string(connString)
 	}
+	// Host ports allocated by the CLI take precedence over config file
+	if data, err := fs.ReadFile(fsys, builder.LocalPortsPath); err == nil {
+		var ports LocalPorts
+		if err := json.Unmarshal(data, &ports); err != nil {
+			return errors.Errorf("failed to parse local ports: %w", err)
+		}
+		c.applyLocalPorts(ports)
+	}
+	// Docker resources are namespaced by a per-worktree project id if allocated
+	if data, err := fs.ReadFile(fsys, builder.LocalProjectIdPath); err == nil && len(data) > 0 {
+		c.ProjectId = strings.TrimSpace(string(data))
+	}
 	if len(c.Api.ExternalUrl) == 0 {
 		// Update external api url
 		apiUrl := url.URL{Host: net.JoinHostPort(c.Hostname,
@@ -692,6 +714,10 @@ func (c *config) Load(path string, fsys fs.FS, overrides ...ConfigEditor) error
 	if version, err := fs.ReadFile(fsys, builder.LogflareVersionPath); err == nil && len(version) > 0 {
 		c.Analytics.Image = replaceImageTag(Images.Logflare, string(version))
 	}
+	// Versions pinned by the team take precedence over linked project versions
+	if err := c.applyVersionLock(builder.VersionLockPath, fsys); err != nil {
+		return err
+	}
 	v := DefaultPgDeltaNpmVersion
 	if version, err := fs.ReadFile(fsys, builder.PgDeltaVersionPath); err == nil {
 		if trimmed := strings.TrimSpace(string(version)); len(trimmed) > 0 {
@@ -803,6 +829,7 @@ func (c *baseConfig) resolve(builder pathBuilder, fsys fs.FS) error {
 			}
 		}
 	}
+	c.Db.Extensions.resolve(builder.SupabaseDirPath)
 	for i, pattern := range c.Db.Migrations.SchemaPaths {
 		if len(pattern) > 0 && !filepath.IsAbs(pattern) {
 			c.Db.Migrations.SchemaPaths[i] = path.Join(builder.SupabaseDirPath, pattern)
@@ -972,6 +999,9 @@ func (c *config) Validate(fsys fs.FS) error {
 		if err := c.Auth.Sms.validate(); err != nil {
 			return err
 		}
+		if c.Auth.Sms.Capture.Enabled && c.Auth.Hook.SendSMS != nil && c.Auth.Hook.SendSMS.Enabled {
+			return errors.New("Invalid config: auth.sms.capture cannot be enabled together with auth.hook.send_sms")
+		}
 		if err := c.Auth.External.validate(); err != nil {
 			return err
 		}
@@ -1015,6 +1045,17 @@ func (c *config) Validate(fsys fs.FS) error {
 	if err := c.Experimental.validate(); err != nil {
 		return err
 	}
+	if err := c.Db.Extensions.validate(fsys); err != nil {
+		return err
+	}
+	if err := c.Local.validate(); err != nil {
+		return err
+	}
+	for section, r := range c.allResources() {
+		if err := r.validate(section); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1174,6 +1215,10 @@ func (e *email) validate(fsys fs.FS) (err error) {
 
 func (s *sms) validate() (err error) {
 	switch {
+	case s.Capture.Enabled:
+		if s.Capture.Port == 0 {
+			return errors.New("Missing required field in config: auth.sms.capture.port")
+		}
 	case s.Twilio.Enabled:
 		if len(s.Twilio.AccountSid) == 0 {
 			return errors.New("Missing required field in config: auth.sms.twilio.account_sid")
@@ -1633,18 +1678,12 @@ func (a *auth) ResolveJWKS(ctx context.Context) (string, error) {
 }
 
 func (c *baseConfig) GetServiceImages() []string {
-	return []string{
-		c.Db.Image,
-		c.Auth.Image,
-		c.Api.Image,
-		c.Realtime.Image,
-		c.Storage.Image,
-		c.EdgeRuntime.Image,
-		c.Studio.Image,
-		c.Studio.PgmetaImage,
-		c.Analytics.Image,
-		c.Db.Pooler.Image,
+	refs := c.serviceImageRefs()
+	images := make([]string, len(refs))
+	for i, image := range refs {
+		images[i] = *image
 	}
+	return images
 }
 
 // Retrieve the final base config to use taking into account the remotes override
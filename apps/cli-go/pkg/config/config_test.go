@@ -552,6 +552,24 @@ func TestValidateHookURI(t *testing.T) {
 	}
 }
 
+func TestValidateSmsCapture(t *testing.T) {
+	t.Run("keeps phone signup without provider", func(t *testing.T) {
+		s := sms{EnableSignup: true, Capture: smsCapture{Enabled: true, Port: 54328}}
+		// Run test
+		assert.NoError(t, s.validate())
+		// Check output
+		assert.True(t, s.EnableSignup)
+	})
+
+	t.Run("throws error on missing port", func(t *testing.T) {
+		s := sms{Capture: smsCapture{Enabled: true}}
+		// Run test
+		err := s.validate()
+		// Check error
+		assert.EqualError(t, err, "Missing required field in config: auth.sms.capture.port")
+	})
+}
+
 func TestGlobFiles(t *testing.T) {
 	t.Run("returns seed files matching patterns", func(t *testing.T) {
 		// Setup in-memory fs
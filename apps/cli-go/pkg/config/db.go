@@ -94,6 +94,8 @@ type (
 		NetworkRestrictions networkRestrictions `toml:"network_restrictions" json:"network_restrictions"`
     SslEnforcement      *sslEnforcement     `toml:"ssl_enforcement" json:"ssl_enforcement"`
 		Vault               map[string]Secret   `toml:"vault" json:"vault"`
+		Extensions          dbExtensions        `toml:"extensions" json:"extensions"`
+		Resources           *Resources          `toml:"resources" json:"resources"`
 	}
 
 	migrations struct {
@@ -107,16 +109,17 @@ type (
 	}
 
 	pooler struct {
-		Enabled          bool     `toml:"enabled" json:"enabled"`
-		Image            string   `toml:"-" json:"-"`
-		Port             uint16   `toml:"port" json:"port"`
-		PoolMode         PoolMode `toml:"pool_mode" json:"pool_mode"`
-		DefaultPoolSize  uint     `toml:"default_pool_size" json:"default_pool_size"`
-		MaxClientConn    uint     `toml:"max_client_conn" json:"max_client_conn"`
-		ConnectionString string   `toml:"-" json:"-"`
-		TenantId         string   `toml:"-" json:"-"`
-		EncryptionKey    string   `toml:"-" json:"-"`
-		SecretKeyBase    string   `toml:"-" json:"-"`
+		Enabled          bool       `toml:"enabled" json:"enabled"`
+		Image            string     `toml:"-" json:"-"`
+		Port             uint16     `toml:"port" json:"port"`
+		PoolMode         PoolMode   `toml:"pool_mode" json:"pool_mode"`
+		DefaultPoolSize  uint       `toml:"default_pool_size" json:"default_pool_size"`
+		MaxClientConn    uint       `toml:"max_client_conn" json:"max_client_conn"`
+		ConnectionString string     `toml:"-" json:"-"`
+		TenantId         string     `toml:"-" json:"-"`
+		EncryptionKey    string     `toml:"-" json:"-"`
+		SecretKeyBase    string     `toml:"-" json:"-"`
+		Resources        *Resources `toml:"resources" json:"resources"`
 	}
 )
 
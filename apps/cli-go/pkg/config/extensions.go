@@ -0,0 +1,56 @@
+package config
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+
+	"github.com/go-errors/errors"
+)
+
+type (
+	// DbExtension is a custom Postgres extension installed into the local database
+	// container, either from a local directory or from a PGXN distribution.
+	DbExtension struct {
+		Path    string `toml:"path" json:"path"`
+		Pgxn    string `toml:"pgxn" json:"pgxn"`
+		Version string `toml:"version" json:"version"`
+	}
+
+	dbExtensions map[string]DbExtension
+)
+
+var extensionPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+func (e dbExtensions) resolve(supabaseDir string) {
+	for name, ext := range e {
+		if len(ext.Path) > 0 && !filepath.IsAbs(ext.Path) {
+			ext.Path = path.Join(supabaseDir, ext.Path)
+			e[name] = ext
+		}
+	}
+}
+
+func (e dbExtensions) validate(fsys fs.FS) error {
+	for name, ext := range e {
+		if !extensionPattern.MatchString(name) {
+			return errors.Errorf("Invalid config for db.extensions: %s (must match %s)", name, extensionPattern.String())
+		}
+		switch {
+		case len(ext.Path) > 0 && len(ext.Pgxn) > 0:
+			return errors.Errorf("Invalid config for db.extensions.%s: path and pgxn are mutually exclusive", name)
+		case len(ext.Path) > 0:
+			if len(ext.Version) > 0 {
+				return errors.Errorf("Invalid config for db.extensions.%s: version is only supported with pgxn", name)
+			}
+			control := path.Join(ext.Path, name+".control")
+			if _, err := fs.Stat(fsys, control); err != nil {
+				return errors.Errorf("Invalid config for db.extensions.%s: failed to find control file: %w", name, err)
+			}
+		case len(ext.Pgxn) == 0:
+			return errors.Errorf("Missing required field in config: db.extensions.%s.path or db.extensions.%s.pgxn", name, name)
+		}
+	}
+	return nil
+}
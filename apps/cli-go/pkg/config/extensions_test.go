@@ -0,0 +1,73 @@
+package config
+
+import (
+	"testing"
+	fs "testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDbExtensions(t *testing.T) {
+	t.Run("parses local and pgxn extensions", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[db.extensions.my_ext]
+			path = "./extensions/my_ext"
+			[db.extensions.pair]
+			pgxn = "pair"
+			version = "0.1.7"
+			`)},
+			"supabase/extensions/my_ext/my_ext.control": &fs.MapFile{},
+		}
+		// Run test
+		require.NoError(t, config.Load("", fsys))
+		// Check parsed values
+		assert.Equal(t, dbExtensions{
+			"my_ext": {Path: "supabase/extensions/my_ext"},
+			"pair":   {Pgxn: "pair", Version: "0.1.7"},
+		}, config.Db.Extensions)
+	})
+
+	t.Run("throws error on missing control file", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[db.extensions.my_ext]
+			path = "./extensions/my_ext"
+			`)},
+		}
+		// Run test
+		assert.ErrorContains(t, config.Load("", fsys), "Invalid config for db.extensions.my_ext: failed to find control file")
+	})
+
+	t.Run("throws error on missing source", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[db.extensions.my_ext]
+			version = "1.0"
+			`)},
+		}
+		// Run test
+		assert.ErrorContains(t, config.Load("", fsys), "Missing required field in config: db.extensions.my_ext.path or db.extensions.my_ext.pgxn")
+	})
+
+	t.Run("throws error on ambiguous source", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[db.extensions.pair]
+			path = "./extensions/pair"
+			pgxn = "pair"
+			`)},
+		}
+		// Run test
+		assert.ErrorContains(t, config.Load("", fsys), "path and pgxn are mutually exclusive")
+	})
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+)
+
+type (
+	local struct {
+		Services map[string]LocalService `toml:"services" json:"services"`
+	}
+
+	// LocalService is a user defined container that runs alongside the local stack.
+	LocalService struct {
+		Image       string            `toml:"image" json:"image"`
+		Command     []string          `toml:"command" json:"command"`
+		Env         map[string]string `toml:"env" json:"env"`
+		Ports       []string          `toml:"ports" json:"ports"`
+		Volumes     []string          `toml:"volumes" json:"volumes"`
+		Healthcheck *LocalHealthcheck `toml:"healthcheck" json:"healthcheck"`
+		Resources   *Resources        `toml:"resources" json:"resources"`
+	}
+
+	LocalHealthcheck struct {
+		Test        []string      `toml:"test" json:"test"`
+		Interval    time.Duration `toml:"interval" json:"interval"`
+		Timeout     time.Duration `toml:"timeout" json:"timeout"`
+		StartPeriod time.Duration `toml:"start_period" json:"start_period"`
+		Retries     int           `toml:"retries" json:"retries"`
+	}
+)
+
+var servicePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+type localEnvKeys struct {
+	Services map[string]struct {
+		Env map[string]any `toml:"env"`
+	} `toml:"services"`
+}
+
+// Viper lower cases all keys: https://github.com/spf13/viper/issues/1014
+// so env keys are restored to the case written in config file.
+func (l *local) restoreEnvKeys(filename string, fsys fs.FS) error {
+	data, err := fs.ReadFile(fsys, filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to read file config: %w", err)
+	}
+	var raw struct {
+		Local   localEnvKeys `toml:"local"`
+		Remotes map[string]struct {
+			Local localEnvKeys `toml:"local"`
+		} `toml:"remotes"`
+	}
+	if _, err := toml.Decode(string(data), &raw); err != nil {
+		return errors.Errorf("failed to decode local services: %w", err)
+	}
+	written := map[string]string{}
+	addKeys := func(keys localEnvKeys) {
+		for name, service := range keys.Services {
+			for k := range service.Env {
+				written[name+"."+strings.ToLower(k)] = k
+			}
+		}
+	}
+	addKeys(raw.Local)
+	for _, remote := range raw.Remotes {
+		addKeys(remote.Local)
+	}
+	for name, service := range l.Services {
+		env := make(map[string]string, len(service.Env))
+		for k, v := range service.Env {
+			if key, ok := written[name+"."+k]; ok {
+				k = key
+			}
+			env[k] = v
+		}
+		service.Env = env
+		l.Services[name] = service
+	}
+	return nil
+}
+
+func (l *local) validate() error {
+	for name, service := range l.Services {
+		if !servicePattern.MatchString(name) {
+			return errors.Errorf("Invalid config for local.services: %s (must match %s)", name, servicePattern.String())
+		}
+		if len(service.Image) == 0 {
+			return errors.Errorf("Missing required field in config: local.services.%s.image", name)
+		}
+		if h := service.Healthcheck; h != nil && len(h.Test) == 0 {
+			return errors.Errorf("Missing required field in config: local.services.%s.healthcheck.test", name)
+		}
+	}
+	return nil
+}
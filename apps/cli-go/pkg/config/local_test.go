@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+	fs "testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalServices(t *testing.T) {
+	t.Run("parses service with env substitution and key case", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[local.services.redis]
+			image = "redis:7-alpine"
+			ports = ["6379:6379"]
+			volumes = ["redis-data:/data"]
+			env = { REDIS_ARGS = "env(REDIS_ARGS)", redisPort = "6379" }
+			healthcheck = { test = ["redis-cli", "ping"], interval = "5s", retries = 3 }
+			`)},
+		}
+		t.Setenv("REDIS_ARGS", "--save 60 1")
+		// Run test
+		require.NoError(t, config.Load("", fsys))
+		// Check parsed values
+		assert.Equal(t, LocalService{
+			Image:   "redis:7-alpine",
+			Ports:   []string{"6379:6379"},
+			Volumes: []string{"redis-data:/data"},
+			Env:     map[string]string{"REDIS_ARGS": "--save 60 1", "redisPort": "6379"},
+			Healthcheck: &LocalHealthcheck{
+				Test:     []string{"redis-cli", "ping"},
+				Interval: 5 * time.Second,
+				Retries:  3,
+			},
+		}, config.Local.Services["redis"])
+	})
+
+	t.Run("throws error on missing image", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[local.services.redis]
+			ports = ["6379:6379"]
+			`)},
+		}
+		// Run test
+		assert.ErrorContains(t, config.Load("", fsys), "Missing required field in config: local.services.redis.image")
+	})
+
+	t.Run("throws error on invalid name", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[local.services."my redis"]
+			image = "redis:7-alpine"
+			`)},
+		}
+		// Run test
+		assert.ErrorContains(t, config.Load("", fsys), "Invalid config for local.services: my redis")
+	})
+}
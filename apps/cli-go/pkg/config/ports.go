@@ -0,0 +1,31 @@
+package config
+
+// LocalPorts maps the names returned by Ports to host ports that override
+// config.toml, such as those allocated by `supabase start --auto-ports`.
+type LocalPorts map[string]uint16
+
+// Ports returns pointers to the host ports of the local stack keyed by name.
+func (c *config) Ports() map[string]*uint16 {
+	return map[string]*uint16{
+		"api":       &c.Api.Port,
+		"db":        &c.Db.Port,
+		"shadow_db": &c.Db.ShadowPort,
+		"pooler":    &c.Db.Pooler.Port,
+		"studio":    &c.Studio.Port,
+		"inbucket":  &c.Inbucket.Port,
+		"smtp":      &c.Inbucket.SmtpPort,
+		"pop3":      &c.Inbucket.Pop3Port,
+		"analytics": &c.Analytics.Port,
+		"inspector": &c.EdgeRuntime.InspectorPort,
+		"sms":       &c.Auth.Sms.Capture.Port,
+	}
+}
+
+func (c *config) applyLocalPorts(ports LocalPorts) {
+	targets := c.Ports()
+	for name, port := range ports {
+		if target, ok := targets[name]; ok && port > 0 {
+			*target = port
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+	fs "testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadLocalPorts(t *testing.T) {
+	t.Run("overrides ports from temp file", func(t *testing.T) {
+		config := NewConfig(WithHostname("127.0.0.1"))
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[api]
+			port = 54321
+			[db]
+			port = 54322
+			`)},
+			"supabase/.temp/ports.json": &fs.MapFile{Data: []byte(`{"api":55321,"db":55322,"unknown":1}`)},
+		}
+		// Run test
+		assert.NoError(t, config.Load("", fsys))
+		// Check derived urls use allocated ports
+		assert.Equal(t, uint16(55321), config.Api.Port)
+		assert.Equal(t, uint16(55322), config.Db.Port)
+		assert.Equal(t, "http://127.0.0.1:55321", config.Api.ExternalUrl)
+	})
+
+	t.Run("throws error on malformed ports", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml":      &fs.MapFile{Data: []byte(`project_id = "bvikqvbczudanvggcord"`)},
+			"supabase/.temp/ports.json": &fs.MapFile{Data: []byte(`{"api":"invalid"}`)},
+		}
+		// Run test
+		assert.ErrorContains(t, config.Load("", fsys), "failed to parse local ports:")
+	})
+}
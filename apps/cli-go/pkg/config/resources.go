@@ -0,0 +1,62 @@
+package config
+
+import (
+	"github.com/go-errors/errors"
+)
+
+// Resources limits the host resources available to the containers of a local service.
+type Resources struct {
+	Memory sizeInBytes `toml:"memory" json:"memory"`
+	Cpus   float64     `toml:"cpus" json:"cpus"`
+}
+
+// Docker rejects memory limits below this size.
+const minMemoryLimit = 6 * 1024 * 1024
+
+func (r *Resources) validate(section string) error {
+	if r == nil {
+		return nil
+	}
+	if r.Memory != 0 && r.Memory < minMemoryLimit {
+		return errors.Errorf("Invalid config for %s.resources.memory: must be at least 6MB", section)
+	}
+	if r.Cpus < 0 {
+		return errors.Errorf("Invalid config for %s.resources.cpus: must not be negative", section)
+	}
+	return nil
+}
+
+// MemoryBytes returns the memory limit in bytes, or 0 if unlimited.
+func (r *Resources) MemoryBytes() int64 {
+	if r == nil {
+		return 0
+	}
+	return int64(r.Memory)
+}
+
+// NanoCpus returns the cpu quota in units of 10^-9 cpus, or 0 if unlimited.
+func (r *Resources) NanoCpus() int64 {
+	if r == nil {
+		return 0
+	}
+	return int64(r.Cpus * 1e9)
+}
+
+func (c *baseConfig) allResources() map[string]*Resources {
+	result := map[string]*Resources{
+		"api":          c.Api.Resources,
+		"db":           c.Db.Resources,
+		"db.pooler":    c.Db.Pooler.Resources,
+		"realtime":     c.Realtime.Resources,
+		"studio":       c.Studio.Resources,
+		"inbucket":     c.Inbucket.Resources,
+		"storage":      c.Storage.Resources,
+		"auth":         c.Auth.Resources,
+		"edge_runtime": c.EdgeRuntime.Resources,
+		"analytics":    c.Analytics.Resources,
+	}
+	for name, service := range c.Local.Services {
+		result["local.services."+name] = service.Resources
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	fs "testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResources(t *testing.T) {
+	t.Run("parses service resources", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[db]
+			resources = { memory = "1GB", cpus = 2 }
+			[analytics]
+			resources = { memory = "512MB", cpus = 0.5 }
+			`)},
+		}
+		// Run test
+		require.NoError(t, config.Load("", fsys))
+		// Check parsed values
+		assert.Equal(t, int64(1024*1024*1024), config.Db.Resources.MemoryBytes())
+		assert.Equal(t, int64(2e9), config.Db.Resources.NanoCpus())
+		assert.Equal(t, int64(512*1024*1024), config.Analytics.Resources.MemoryBytes())
+		assert.Equal(t, int64(5e8), config.Analytics.Resources.NanoCpus())
+		// Unset resources are unlimited
+		assert.Nil(t, config.Auth.Resources)
+		assert.Zero(t, config.Auth.Resources.MemoryBytes())
+		assert.Zero(t, config.Auth.Resources.NanoCpus())
+	})
+
+	t.Run("throws error on low memory limit", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[studio]
+			resources = { memory = "1MB" }
+			`)},
+		}
+		// Run test
+		assert.ErrorContains(t, config.Load("", fsys), "Invalid config for studio.resources.memory: must be at least 6MB")
+	})
+
+	t.Run("throws error on negative cpus", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[db.pooler]
+			resources = { cpus = -1 }
+			`)},
+		}
+		// Run test
+		assert.ErrorContains(t, config.Load("", fsys), "Invalid config for db.pooler.resources.cpus: must not be negative")
+	})
+}
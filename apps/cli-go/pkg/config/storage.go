@@ -12,6 +12,7 @@ type (
 		Image               string               `toml:"-" json:"-"`
 		TargetMigration     string               `toml:"-" json:"-"`
 		ImgProxyImage       string               `toml:"-" json:"-"`
+		Resources           *Resources           `toml:"resources" json:"resources"`
 		FileSizeLimit       sizeInBytes          `toml:"file_size_limit" json:"file_size_limit"`
 		ImageTransformation *imageTransformation `toml:"image_transformation" json:"image_transformation"`
 		S3Protocol          *s3Protocol          `toml:"s3_protocol" json:"s3_protocol"`
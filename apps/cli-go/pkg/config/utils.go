@@ -13,10 +13,13 @@ type pathBuilder struct {
 	SupabaseDirPath        string
 	ConfigPath             string
 	GitIgnorePath          string
+	VersionLockPath        string
 	TempDir                string
 	ImportMapsDir          string
 	ProjectRefPath         string
 	PoolerUrlPath          string
+	LocalPortsPath         string
+	LocalProjectIdPath     string
 	PostgresVersionPath    string
 	GotrueVersionPath      string
 	RestVersionPath        string
@@ -50,10 +53,13 @@ func NewPathBuilder(configPath string) pathBuilder {
 		SupabaseDirPath:        base,
 		ConfigPath:             configPath,
 		GitIgnorePath:          filepath.Join(base, ".gitignore"),
+		VersionLockPath:        filepath.Join(base, "versions.lock.json"),
 		TempDir:                filepath.Join(base, ".temp"),
 		ImportMapsDir:          filepath.Join(base, ".temp", "import_maps"),
 		ProjectRefPath:         filepath.Join(base, ".temp", "project-ref"),
 		PoolerUrlPath:          filepath.Join(base, ".temp", "pooler-url"),
+		LocalPortsPath:         filepath.Join(base, ".temp", "ports.json"),
+		LocalProjectIdPath:     filepath.Join(base, ".temp", "project-id"),
 		PostgresVersionPath:    filepath.Join(base, ".temp", "postgres-version"),
 		GotrueVersionPath:      filepath.Join(base, ".temp", "gotrue-version"),
 		RestVersionPath:        filepath.Join(base, ".temp", "rest-version"),
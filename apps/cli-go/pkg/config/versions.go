@@ -0,0 +1,86 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// VersionLock maps service image names, ie. gotrue, to the tags pinned for
+// the whole team in a committed lockfile.
+type VersionLock map[string]string
+
+// ImageName returns the repository name of an image without its org and tag,
+// ie. supabase/gotrue:v2.170.0 becomes gotrue.
+func ImageName(image string) string {
+	name, _, _ := strings.Cut(path.Base(image), ":")
+	return name
+}
+
+func LoadVersionLock(lockPath string, fsys fs.FS) (VersionLock, error) {
+	lock := VersionLock{}
+	data, err := fs.ReadFile(fsys, lockPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return lock, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read version lock: %w", err)
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, errors.Errorf("failed to parse version lock: %w", err)
+	}
+	return lock, nil
+}
+
+// serviceImageRefs returns pointers to the image of each service that can be
+// pinned, so that callers may read or replace them in place.
+func (c *baseConfig) serviceImageRefs() []*string {
+	return []*string{
+		&c.Db.Image,
+		&c.Auth.Image,
+		&c.Api.Image,
+		&c.Realtime.Image,
+		&c.Storage.Image,
+		&c.EdgeRuntime.Image,
+		&c.Studio.Image,
+		&c.Studio.PgmetaImage,
+		&c.Analytics.Image,
+		&c.Db.Pooler.Image,
+	}
+}
+
+// applyVersionLock replaces service image tags with those pinned in the lockfile.
+func (c *baseConfig) applyVersionLock(lockPath string, fsys fs.FS) error {
+	lock, err := LoadVersionLock(lockPath, fsys)
+	if err != nil {
+		return err
+	}
+	for _, image := range c.serviceImageRefs() {
+		tag, ok := lock[ImageName(*image)]
+		if !ok || len(tag) == 0 {
+			continue
+		}
+		if image == &c.Db.Image && !strings.HasPrefix(tag, fmt.Sprintf("%d.", c.Db.MajorVersion)) {
+			return errors.Errorf("Invalid version lock: %s pins postgres %s which does not match db.major_version %d", lockPath, tag, c.Db.MajorVersion)
+		}
+		*image = replaceImageTag(*image, tag)
+	}
+	return nil
+}
+
+// GetBundledServiceImages returns the service images bundled with this release
+// of the CLI, in the same order as GetServiceImages.
+func (c *baseConfig) GetBundledServiceImages() []string {
+	latest := NewConfig().baseConfig
+	switch c.Db.MajorVersion {
+	case 13, 15:
+		latest.Db.Image = pg15
+	case 14:
+		latest.Db.Image = pg14
+	}
+	return latest.GetServiceImages()
+}
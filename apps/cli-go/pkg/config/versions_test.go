@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+	fs "testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionLock(t *testing.T) {
+	t.Run("pins service versions over linked versions", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[db]
+			major_version = 17
+			`)},
+			"supabase/.temp/gotrue-version":  &fs.MapFile{Data: []byte("v2.100.0")},
+			"supabase/.temp/storage-version": &fs.MapFile{Data: []byte("v1.0.0")},
+			"supabase/versions.lock.json": &fs.MapFile{Data: []byte(`{
+				"postgres": "17.4.1.054",
+				"gotrue": "v2.170.0"
+			}`)},
+		}
+		// Run test
+		require.NoError(t, config.Load("", fsys))
+		// Check parsed values
+		assert.Equal(t, "supabase/postgres:17.4.1.054", config.Db.Image)
+		assert.Equal(t, "supabase/gotrue:v2.170.0", config.Auth.Image)
+		assert.Equal(t, "supabase/storage-api:v1.0.0", config.Storage.Image)
+	})
+
+	t.Run("throws error on mismatched postgres version", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "bvikqvbczudanvggcord"
+			[db]
+			major_version = 15
+			`)},
+			"supabase/versions.lock.json": &fs.MapFile{Data: []byte(`{"postgres": "17.4.1.054"}`)},
+		}
+		// Run test
+		err := config.Load("", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "pins postgres 17.4.1.054 which does not match db.major_version 15")
+	})
+
+	t.Run("throws error on malformed lockfile", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml":        &fs.MapFile{Data: []byte(`project_id = "bvikqvbczudanvggcord"`)},
+			"supabase/versions.lock.json": &fs.MapFile{Data: []byte(`[]`)},
+		}
+		// Run test
+		err := config.Load("", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse version lock:")
+	})
+}
+
+func TestImageName(t *testing.T) {
+	assert.Equal(t, "gotrue", ImageName("supabase/gotrue:v2.170.0"))
+	assert.Equal(t, "postgres", ImageName("public.ecr.aws/supabase/postgres:17.4.1.054"))
+	assert.Equal(t, "studio", ImageName("studio"))
+}
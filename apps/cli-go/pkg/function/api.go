@@ -3,6 +3,7 @@ package function
 import (
 	"context"
 	"io"
+	"io/fs"
 
 	"github.com/supabase/cli/pkg/api"
 )
@@ -12,6 +13,15 @@ type EdgeRuntimeAPI struct {
 	client  api.ClientWithResponses
 	eszip   EszipBundler
 	maxJobs uint
+	force   bool
+	dryRun  bool
+	hashes  *sourceHashOption
+}
+
+type sourceHashOption struct {
+	hashes      SourceHashes
+	denoVersion uint
+	fsys        fs.FS
 }
 
 type FunctionDeployMetadata struct {
@@ -21,6 +31,7 @@ type FunctionDeployMetadata struct {
 	StaticPatterns *[]string `json:"static_patterns,omitempty"`
 	VerifyJwt      *bool     `json:"verify_jwt,omitempty"`
 	SHA256         string    `json:"sha256,omitempty"`
+	SourceHash     string    `json:"-"`
 }
 
 type EszipBundler interface {
@@ -51,3 +62,26 @@ func WithMaxJobs(maxJobs uint) withOption {
 		era.maxJobs = maxJobs
 	}
 }
+
+// WithSourceHashes skips bundling functions whose source is unchanged since
+// the last deploy. Hashes of newly deployed functions are written back to the
+// given map.
+func WithSourceHashes(hashes SourceHashes, denoVersion uint, fsys fs.FS) withOption {
+	return func(era *EdgeRuntimeAPI) {
+		era.hashes = &sourceHashOption{hashes: hashes, denoVersion: denoVersion, fsys: fsys}
+	}
+}
+
+// WithForce redeploys functions even if they are unchanged.
+func WithForce(force bool) withOption {
+	return func(era *EdgeRuntimeAPI) {
+		era.force = force
+	}
+}
+
+// WithDryRun lists the functions that would be deployed without deploying them.
+func WithDryRun(dryRun bool) withOption {
+	return func(era *EdgeRuntimeAPI) {
+		era.dryRun = dryRun
+	}
+}
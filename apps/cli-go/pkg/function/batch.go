@@ -16,6 +16,7 @@ import (
 	"github.com/docker/go-units"
 	"github.com/go-errors/errors"
 	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
 	"github.com/supabase/cli/pkg/config"
 )
 
@@ -25,24 +26,11 @@ const (
 )
 
 func (s *EdgeRuntimeAPI) UpsertFunctions(ctx context.Context, functionConfig config.FunctionConfig, filter ...func(string) bool) error {
-	policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(), maxRetries), ctx)
-	result, err := backoff.RetryWithData(func() ([]api.FunctionResponse, error) {
-		resp, err := s.client.V1ListAllFunctionsWithResponse(ctx, s.project)
-		if err != nil {
-			return nil, errors.Errorf("failed to list functions: %w", err)
-		} else if resp.JSON200 == nil {
-			err = errors.Errorf("unexpected list functions status %d: %s", resp.StatusCode(), string(resp.Body))
-			if resp.StatusCode() < http.StatusInternalServerError {
-				err = &backoff.PermanentError{Err: err}
-			}
-			return nil, err
-		}
-		return *resp.JSON200, nil
-	}, policy)
+	result, err := s.listFunctions(ctx)
 	if err != nil {
 		return err
 	}
-	policy.Reset()
+	policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(), maxRetries), ctx)
 	slugToIndex := make(map[string]int, len(result))
 	for i, f := range result {
 		slugToIndex[f.Slug] = i
@@ -59,6 +47,24 @@ OUTER:
 				continue OUTER
 			}
 		}
+		i, exists := slugToIndex[slug]
+		unchanged := func(ezbrSha256 *string) bool {
+			return !s.force && exists && i >= 0 &&
+				result[i].EzbrSha256 != nil && *result[i].EzbrSha256 == cast.Val(ezbrSha256, "") &&
+				result[i].VerifyJwt != nil && *result[i].VerifyJwt == function.VerifyJWT
+		}
+		// Skip bundling if source has not changed since the last deploy
+		sourceHash, err := s.sourceHash(function.Entrypoint, function.ImportMap, function.StaticFiles)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "WARN:", err)
+		} else if last := s.lastEzbrSha256(slug, sourceHash); last != nil && unchanged(last) {
+			fmt.Fprintln(os.Stderr, "No change found in Function:", slug)
+			continue
+		}
+		if s.dryRun {
+			fmt.Fprintln(os.Stderr, "Would deploy Function:", slug)
+			continue
+		}
 		var body bytes.Buffer
 		meta, err := s.eszip.Bundle(ctx, slug, function.Entrypoint, function.ImportMap, function.StaticFiles, &body)
 		if errors.Is(err, ErrNoDeploy) {
@@ -71,10 +77,9 @@ OUTER:
 		bodyHash := sha256.Sum256(body.Bytes())
 		meta.SHA256 = hex.EncodeToString(bodyHash[:])
 		// Skip if function has not changed
-		if i, exists := slugToIndex[slug]; exists && i >= 0 &&
-			result[i].EzbrSha256 != nil && *result[i].EzbrSha256 == meta.SHA256 &&
-			result[i].VerifyJwt != nil && *result[i].VerifyJwt == function.VerifyJWT {
+		if unchanged(&meta.SHA256) {
 			fmt.Fprintln(os.Stderr, "No change found in Function:", slug)
+			s.saveSourceHash(slug, sourceHash, &meta.SHA256)
 			continue
 		}
 		// Update if function already exists
@@ -95,6 +100,7 @@ OUTER:
 			return err
 		}
 		toUpdate = append(toUpdate, result...)
+		s.saveSourceHash(slug, sourceHash, &meta.SHA256)
 		policy.Reset()
 	}
 	if len(toUpdate) > 1 {
@@ -112,6 +118,48 @@ OUTER:
 	return nil
 }
 
+func (s *EdgeRuntimeAPI) listFunctions(ctx context.Context) ([]api.FunctionResponse, error) {
+	policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(), maxRetries), ctx)
+	return backoff.RetryWithData(func() ([]api.FunctionResponse, error) {
+		resp, err := s.client.V1ListAllFunctionsWithResponse(ctx, s.project)
+		if err != nil {
+			return nil, errors.Errorf("failed to list functions: %w", err)
+		} else if resp.JSON200 == nil {
+			err = errors.Errorf("unexpected list functions status %d: %s", resp.StatusCode(), string(resp.Body))
+			if resp.StatusCode() < http.StatusInternalServerError {
+				err = &backoff.PermanentError{Err: err}
+			}
+			return nil, err
+		}
+		return *resp.JSON200, nil
+	}, policy)
+}
+
+// sourceHash returns an empty string if source hashing is disabled.
+func (s *EdgeRuntimeAPI) sourceHash(entrypoint, importMap string, staticFiles []string) (string, error) {
+	if s.hashes == nil {
+		return "", nil
+	}
+	return SourceHash(entrypoint, importMap, staticFiles, s.hashes.denoVersion, s.hashes.fsys)
+}
+
+// lastEzbrSha256 returns the eszip hash last deployed from the same source.
+func (s *EdgeRuntimeAPI) lastEzbrSha256(slug, sourceHash string) *string {
+	if s.hashes == nil || len(sourceHash) == 0 {
+		return nil
+	}
+	if last, ok := s.hashes.hashes[slug]; ok && last.Source == sourceHash {
+		return &last.EzbrSha256
+	}
+	return nil
+}
+
+func (s *EdgeRuntimeAPI) saveSourceHash(slug, sourceHash string, ezbrSha256 *string) {
+	if s.hashes != nil && len(sourceHash) > 0 && len(cast.Val(ezbrSha256, "")) > 0 {
+		s.hashes.hashes[slug] = DeployedHash{Source: sourceHash, EzbrSha256: *ezbrSha256}
+	}
+}
+
 func (s *EdgeRuntimeAPI) updateFunction(ctx context.Context, slug string, meta FunctionDeployMetadata, body io.Reader) (api.BulkUpdateFunctionBody, error) {
 	resp, err := s.client.V1UpdateAFunctionWithBodyWithResponse(ctx, s.project, slug, &api.V1UpdateAFunctionParams{
 		VerifyJwt:      meta.VerifyJwt,
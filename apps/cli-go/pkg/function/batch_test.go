@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	fs "testing/fstest"
 
 	"github.com/h2non/gock"
 	"github.com/stretchr/testify/assert"
@@ -30,6 +31,13 @@ func (b *MockBundler) Bundle(ctx context.Context, slug, entrypoint, importMap st
 	}, nil
 }
 
+type FailBundler struct {
+}
+
+func (b *FailBundler) Bundle(ctx context.Context, slug, entrypoint, importMap string, staticFiles []string, output io.Writer) (FunctionDeployMetadata, error) {
+	return FunctionDeployMetadata{}, errors.New("unexpected bundle")
+}
+
 func mockClient(t *testing.T) EdgeRuntimeAPI {
 	apiClient, err := api.NewClientWithResponses(mockApiHost)
 	require.NoError(t, err)
@@ -105,6 +113,144 @@ func TestUpsertFunctions(t *testing.T) {
 		assert.Empty(t, gock.GetUnmatchedRequests())
 	})
 
+	t.Run("skips bundling unchanged source", func(t *testing.T) {
+		fsys := fs.MapFS{"test-a/index.ts": &fs.MapFile{Data: []byte("Deno.serve()")}}
+		source, err := SourceHash("test-a/index.ts", "", nil, 2, fsys)
+		require.NoError(t, err)
+		hashes := SourceHashes{"test-a": {Source: source, EzbrSha256: "deployed"}}
+		client := NewEdgeRuntimeAPI(mockProject, client.client, WithBundler(&FailBundler{}), WithSourceHashes(hashes, 2, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{
+				Slug:       "test-a",
+				VerifyJwt:  cast.Ptr(true),
+				EzbrSha256: cast.Ptr("deployed"),
+			}})
+		// Run test
+		err = client.UpsertFunctions(context.Background(), config.FunctionConfig{
+			"test-a": {Enabled: true, VerifyJWT: true, Entrypoint: "test-a/index.ts"},
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, gock.Pending())
+		assert.Empty(t, gock.GetUnmatchedRequests())
+	})
+
+	t.Run("skips unchanged bundle without source hash", func(t *testing.T) {
+		fsys := fs.MapFS{"test-a/index.ts": &fs.MapFile{Data: []byte("Deno.serve()")}}
+		source, err := SourceHash("test-a/index.ts", "", nil, 2, fsys)
+		require.NoError(t, err)
+		hashes := SourceHashes{}
+		client := NewEdgeRuntimeAPI(mockProject, client.client, WithBundler(&MockBundler{}), WithSourceHashes(hashes, 2, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{
+				Slug:       "test-a",
+				VerifyJwt:  cast.Ptr(true),
+				EzbrSha256: cast.Ptr("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+			}})
+		// Run test
+		err = client.UpsertFunctions(context.Background(), config.FunctionConfig{
+			"test-a": {Enabled: true, VerifyJWT: true, Entrypoint: "test-a/index.ts"},
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, SourceHashes{"test-a": {
+			Source:     source,
+			EzbrSha256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		}}, hashes)
+		assert.Empty(t, gock.Pending())
+		assert.Empty(t, gock.GetUnmatchedRequests())
+	})
+
+	t.Run("bundles source deployed by another machine", func(t *testing.T) {
+		fsys := fs.MapFS{"test-a/index.ts": &fs.MapFile{Data: []byte("Deno.serve()")}}
+		source, err := SourceHash("test-a/index.ts", "", nil, 2, fsys)
+		require.NoError(t, err)
+		hashes := SourceHashes{"test-a": {Source: source, EzbrSha256: "stale"}}
+		client := NewEdgeRuntimeAPI(mockProject, client.client, WithBundler(&MockBundler{}), WithSourceHashes(hashes, 2, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{
+				Slug:       "test-a",
+				VerifyJwt:  cast.Ptr(true),
+				EzbrSha256: cast.Ptr("deployed"),
+			}})
+		gock.New(mockApiHost).
+			Patch("/v1/projects/" + mockProject + "/functions/test-a").
+			Reply(http.StatusOK).
+			JSON(api.FunctionResponse{Slug: "test-a"})
+		// Run test
+		err = client.UpsertFunctions(context.Background(), config.FunctionConfig{
+			"test-a": {Enabled: true, VerifyJWT: true, Entrypoint: "test-a/index.ts"},
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", hashes["test-a"].EzbrSha256)
+		assert.Empty(t, gock.Pending())
+		assert.Empty(t, gock.GetUnmatchedRequests())
+	})
+
+	t.Run("redeploys unchanged with force", func(t *testing.T) {
+		fsys := fs.MapFS{"test-a/index.ts": &fs.MapFile{Data: []byte("Deno.serve()")}}
+		source, err := SourceHash("test-a/index.ts", "", nil, 2, fsys)
+		require.NoError(t, err)
+		hashes := SourceHashes{"test-a": {
+			Source:     source,
+			EzbrSha256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		}}
+		client := NewEdgeRuntimeAPI(mockProject, client.client, WithBundler(&MockBundler{}), WithSourceHashes(hashes, 2, fsys), WithForce(true))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{
+				Slug:       "test-a",
+				VerifyJwt:  cast.Ptr(true),
+				EzbrSha256: cast.Ptr("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+			}})
+		gock.New(mockApiHost).
+			Patch("/v1/projects/" + mockProject + "/functions/test-a").
+			Reply(http.StatusOK).
+			JSON(api.FunctionResponse{Slug: "test-a"})
+		// Run test
+		err = client.UpsertFunctions(context.Background(), config.FunctionConfig{
+			"test-a": {Enabled: true, VerifyJWT: true, Entrypoint: "test-a/index.ts"},
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, gock.Pending())
+		assert.Empty(t, gock.GetUnmatchedRequests())
+	})
+
+	t.Run("lists changed functions on dry run", func(t *testing.T) {
+		client := NewEdgeRuntimeAPI(mockProject, client.client, WithBundler(&FailBundler{}), WithDryRun(true))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		// Run test
+		err := client.UpsertFunctions(context.Background(), config.FunctionConfig{
+			"test-a": {Enabled: true},
+		})
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, gock.Pending())
+		assert.Empty(t, gock.GetUnmatchedRequests())
+	})
+
 	t.Run("handles concurrent deploy", func(t *testing.T) {
 		// Setup mock api
 		defer gock.OffAll()
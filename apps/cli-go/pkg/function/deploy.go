@@ -23,6 +23,14 @@ func (s *EdgeRuntimeAPI) Deploy(ctx context.Context, functionConfig config.Funct
 	if s.eszip != nil {
 		return s.UpsertFunctions(ctx, functionConfig)
 	}
+	// Skip functions whose deployed bundle was built from the same source
+	var result []api.FunctionResponse
+	if s.hashes != nil && len(s.hashes.hashes) > 0 && !s.force {
+		var err error
+		if result, err = s.listFunctions(ctx); err != nil {
+			return err
+		}
+	}
 	// Convert all paths in functions config to relative when using api deploy
 	var toDeploy []FunctionDeployMetadata
 	for slug, fc := range functionConfig {
@@ -41,18 +49,46 @@ func (s *EdgeRuntimeAPI) Deploy(ctx context.Context, functionConfig config.Funct
 			files[i] = toRelPath(sf)
 		}
 		meta.StaticPatterns = &files
+		if sourceHash, err := s.sourceHash(meta.EntrypointPath, *meta.ImportMapPath, files); err != nil {
+			fmt.Fprintln(os.Stderr, "WARN:", err)
+		} else if last := s.lastEzbrSha256(slug, sourceHash); last != nil && isDeployed(result, slug, *last, fc.VerifyJWT) {
+			fmt.Fprintln(os.Stderr, "No change found in Function:", slug)
+			continue
+		} else {
+			meta.SourceHash = sourceHash
+		}
 		toDeploy = append(toDeploy, meta)
 	}
 	if len(toDeploy) == 0 {
 		return errors.New(ErrNoDeploy)
+	} else if s.dryRun {
+		for _, meta := range toDeploy {
+			fmt.Fprintln(os.Stderr, "Would deploy Function:", *meta.Name)
+		}
+		return nil
 	} else if len(toDeploy) == 1 {
 		param := api.V1DeployAFunctionParams{Slug: toDeploy[0].Name}
-		_, err := s.upload(ctx, param, toDeploy[0], fsys)
-		return err
+		resp, err := s.upload(ctx, param, toDeploy[0], fsys)
+		if err != nil {
+			return err
+		}
+		s.saveSourceHash(*toDeploy[0].Name, toDeploy[0].SourceHash, resp.EzbrSha256)
+		return nil
 	}
 	return s.bulkUpload(ctx, toDeploy, fsys)
 }
 
+// isDeployed checks that the function has not been redeployed since the given bundle.
+func isDeployed(functions []api.FunctionResponse, slug, ezbrSha256 string, verifyJwt bool) bool {
+	for _, f := range functions {
+		if f.Slug == slug {
+			return cast.Val(f.EzbrSha256, "") == ezbrSha256 &&
+				cast.Val(f.VerifyJwt, !verifyJwt) == verifyJwt
+		}
+	}
+	return false
+}
+
 func toRelPath(fp string) string {
 	if filepath.IsAbs(fp) {
 		if cwd, err := os.Getwd(); err == nil {
@@ -88,6 +124,7 @@ func (s *EdgeRuntimeAPI) bulkUpload(ctx context.Context, toDeploy []FunctionDepl
 			toUpdate[i].VerifyJwt = resp.VerifyJwt
 			toUpdate[i].Status = api.BulkUpdateFunctionBodyStatus(resp.Status)
 			toUpdate[i].CreatedAt = resp.CreatedAt
+			toUpdate[i].EzbrSha256 = resp.EzbrSha256
 			return nil
 		}
 		if err := jq.Put(bundle); err != nil {
@@ -102,6 +139,9 @@ func (s *EdgeRuntimeAPI) bulkUpload(ctx context.Context, toDeploy []FunctionDepl
 	} else if resp.JSON200 == nil {
 		return errors.Errorf("unexpected bulk update status %d: %s", resp.StatusCode(), string(resp.Body))
 	}
+	for i, meta := range toDeploy {
+		s.saveSourceHash(*meta.Name, meta.SourceHash, toUpdate[i].EzbrSha256)
+	}
 	return nil
 }
 
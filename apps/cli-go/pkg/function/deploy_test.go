@@ -138,6 +138,57 @@ func TestDeployAll(t *testing.T) {
 		assert.Empty(t, gock.GetUnmatchedRequests())
 	})
 
+	t.Run("skips unchanged source", func(t *testing.T) {
+		c := config.FunctionConfig{
+			"test-ts": {
+				Enabled:    true,
+				VerifyJWT:  true,
+				Entrypoint: "testdata/shared/whatever.ts",
+			},
+			"test-js": {
+				Enabled:    true,
+				Entrypoint: "testdata/geometries/Geometries.js",
+			},
+		}
+		// Setup in-memory fs
+		fsys := testImports
+		source, err := SourceHash("testdata/shared/whatever.ts", "", nil, 2, fsys)
+		require.NoError(t, err)
+		hashes := SourceHashes{
+			"test-ts": {Source: source, EzbrSha256: "deployed"},
+			"test-js": {Source: source, EzbrSha256: "deployed"},
+		}
+		client := NewEdgeRuntimeAPI(mockProject, *apiClient, WithSourceHashes(hashes, 2, fsys))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{
+				Slug:       "test-ts",
+				VerifyJwt:  cast.Ptr(true),
+				EzbrSha256: cast.Ptr("deployed"),
+			}, {
+				Slug:       "test-js",
+				VerifyJwt:  cast.Ptr(false),
+				EzbrSha256: cast.Ptr("deployed"),
+			}})
+		gock.New(mockApiHost).
+			Post("/v1/projects/"+mockProject+"/functions/deploy").
+			MatchParam("slug", "test-js").
+			Reply(http.StatusCreated).
+			JSON(api.DeployFunctionResponse{Slug: "test-js", EzbrSha256: cast.Ptr("bundled")})
+		// Run test
+		err = client.Deploy(context.Background(), c, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "deployed", hashes["test-ts"].EzbrSha256)
+		assert.NotEqual(t, source, hashes["test-js"].Source)
+		assert.Equal(t, "bundled", hashes["test-js"].EzbrSha256)
+		assert.Empty(t, gock.Pending())
+		assert.Empty(t, gock.GetUnmatchedRequests())
+	})
+
 	t.Run("throws error on network failure", func(t *testing.T) {
 		errNetwork := errors.New("network")
 		c := config.FunctionConfig{"demo": {Enabled: true}}
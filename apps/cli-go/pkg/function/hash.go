@@ -0,0 +1,65 @@
+package function
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// DeployedHash records the source hash of a function alongside the eszip hash
+// that was deployed from it.
+type DeployedHash struct {
+	Source     string `json:"source"`
+	EzbrSha256 string `json:"ezbr_sha256"`
+}
+
+// SourceHashes maps function slugs to the hashes of their last deploy.
+type SourceHashes map[string]DeployedHash
+
+// SourceHash computes a hash over every input to the bundler, ie. the entrypoint,
+// import map, static files, local imports, and the Deno version.
+func SourceHash(entrypoint, importMap string, staticFiles []string, denoVersion uint, fsys fs.FS) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "deno:%d\x00", denoVersion)
+	addFile := func(srcPath string, r io.Reader) error {
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(srcPath))
+		if _, err := io.Copy(h, r); err != nil {
+			return errors.Errorf("failed to hash file: %w", err)
+		}
+		_, err := h.Write([]byte{0})
+		return err
+	}
+	im := ImportMap{}
+	if len(importMap) > 0 {
+		if err := im.LoadAsDeno(filepath.ToSlash(importMap), fsys, addFile); err != nil {
+			return "", err
+		}
+	}
+	files, err := config.Glob(staticFiles).Files(fsys)
+	if err != nil {
+		return "", err
+	}
+	readFile := func(srcPath string, w io.Writer) error {
+		f, err := fsys.Open(filepath.FromSlash(srcPath))
+		if err != nil {
+			return errors.Errorf("failed to read file: %w", err)
+		}
+		defer f.Close()
+		return addFile(srcPath, io.TeeReader(f, w))
+	}
+	for _, sfPath := range files {
+		if err := readFile(sfPath, io.Discard); err != nil {
+			return "", err
+		}
+	}
+	if err := im.WalkImportPaths(filepath.ToSlash(entrypoint), readFile); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,48 @@
+package function
+
+import (
+	"testing"
+	fs "testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceHash(t *testing.T) {
+	newFS := func(shared string) fs.MapFS {
+		return fs.MapFS{
+			"functions/hello/index.ts":   &fs.MapFile{Data: []byte(`import { greet } from "shared/greet.ts";`)},
+			"functions/hello/deno.json":  &fs.MapFile{Data: []byte(`{"imports":{"shared/":"../_shared/"}}`)},
+			"functions/hello/data.txt":   &fs.MapFile{Data: []byte("static")},
+			"functions/_shared/greet.ts": &fs.MapFile{Data: []byte(shared)},
+		}
+	}
+	hash := func(t *testing.T, fsys fs.MapFS, denoVersion uint) string {
+		result, err := SourceHash("functions/hello/index.ts", "functions/hello/deno.json", []string{"functions/hello/*.txt"}, denoVersion, fsys)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("is stable for the same source", func(t *testing.T) {
+		assert.Equal(t, hash(t, newFS("v1"), 2), hash(t, newFS("v1"), 2))
+	})
+
+	t.Run("changes with local imports", func(t *testing.T) {
+		assert.NotEqual(t, hash(t, newFS("v1"), 2), hash(t, newFS("v2"), 2))
+	})
+
+	t.Run("changes with static files", func(t *testing.T) {
+		fsys := newFS("v1")
+		fsys["functions/hello/data.txt"] = &fs.MapFile{Data: []byte("changed")}
+		assert.NotEqual(t, hash(t, newFS("v1"), 2), hash(t, fsys, 2))
+	})
+
+	t.Run("changes with deno version", func(t *testing.T) {
+		assert.NotEqual(t, hash(t, newFS("v1"), 1), hash(t, newFS("v1"), 2))
+	})
+
+	t.Run("throws error on missing import map", func(t *testing.T) {
+		_, err := SourceHash("functions/hello/index.ts", "functions/missing.json", nil, 2, newFS("v1"))
+		assert.ErrorContains(t, err, "failed to load import map:")
+	})
+}